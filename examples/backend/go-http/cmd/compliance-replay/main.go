@@ -0,0 +1,89 @@
+// Command compliance-replay re-emits a compliance evidence WAL to an OTLP
+// endpoint. It's for auditor handoff: point it at a copy of the WAL
+// directory and a collector the auditor controls, and every span still held
+// in the WAL's segments — acknowledged or not — gets replayed there,
+// regardless of whether the original endpoint ever received it. Segments
+// the pump has already removed, and cold archives written by compaction,
+// are not read.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/export"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+func main() {
+	var (
+		dir      = flag.String("dir", "", "WAL directory to replay (required)")
+		endpoint = flag.String("endpoint", "", "OTLP/HTTP endpoint to replay spans to (required)")
+		insecure = flag.Bool("insecure", false, "disable TLS when connecting to -endpoint")
+	)
+	flag.Parse()
+
+	if *dir == "" || *endpoint == "" {
+		fmt.Fprintln(os.Stderr, "usage: compliance-replay -dir <wal-dir> -endpoint <otlp-endpoint> [-insecure]")
+		os.Exit(2)
+	}
+
+	if err := replay(*dir, *endpoint, *insecure); err != nil {
+		log.Fatalf("compliance-replay: %v", err)
+	}
+}
+
+func replay(dir, endpoint string, insecure bool) error {
+	wal, err := export.OpenWAL(dir)
+	if err != nil {
+		return fmt.Errorf("opening WAL at %s: %w", dir, err)
+	}
+	defer wal.Close()
+
+	segments, err := wal.Segments()
+	if err != nil {
+		return fmt.Errorf("listing segments: %w", err)
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	client := otlptracehttp.NewClient(opts...)
+
+	ctx := context.Background()
+	if err := client.Start(ctx); err != nil {
+		return fmt.Errorf("connecting to %s: %w", endpoint, err)
+	}
+	defer client.Stop(ctx)
+
+	seen := make(map[string]struct{})
+	var replayed int
+
+	for _, name := range segments {
+		records, err := export.ReadSegment(wal.Path(name))
+		if err != nil {
+			return fmt.Errorf("reading segment %s: %w", name, err)
+		}
+
+		batch := export.Dedupe(records, seen)
+		if len(batch) == 0 {
+			continue
+		}
+		if err := client.UploadTraces(ctx, batch); err != nil {
+			return fmt.Errorf("replaying segment %s to %s: %w", name, endpoint, err)
+		}
+		for _, rs := range batch {
+			for _, ss := range rs.GetScopeSpans() {
+				replayed += len(ss.GetSpans())
+			}
+		}
+		log.Printf("replayed segment %s", name)
+	}
+
+	log.Printf("replay complete: %d spans across %d segments", replayed, len(segments))
+	return nil
+}
@@ -0,0 +1,165 @@
+// Command compliance-report turns evidence spans recorded by
+// BeginGDPRSpan/BeginSOC2Span into auditor-ready artifacts: a
+// control-coverage matrix, a gap report of controls that produced no
+// evidence, and a sample of evidence packets per control. It reads spans
+// from a durable exporter's WAL directory and/or its cold archives —
+// whatever's on disk for the window being audited — rather than querying
+// a trace backend directly.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/export"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/report"
+)
+
+func main() {
+	var (
+		walDir   = flag.String("wal", "", "WAL directory to analyze")
+		coldGlob = flag.String("cold", "", "glob of cold archive files to analyze")
+		since    = flag.String("since", "", "RFC3339 start of the analysis window (default: unbounded)")
+		until    = flag.String("until", "", "RFC3339 end of the analysis window (default: unbounded)")
+		sample   = flag.Int("sample", 3, "evidence packets to sample per control")
+		out      = flag.String("out", "", "output directory for report.json/md/html/pdf (required)")
+	)
+	flag.Parse()
+
+	if *walDir == "" && *coldGlob == "" {
+		fmt.Fprintln(os.Stderr, "usage: compliance-report [-wal <dir>] [-cold <glob>] [-since <rfc3339>] [-until <rfc3339>] [-sample N] -out <dir>")
+		os.Exit(2)
+	}
+	if *out == "" {
+		fmt.Fprintln(os.Stderr, "compliance-report: -out is required")
+		os.Exit(2)
+	}
+
+	window, err := parseWindow(*since, *until)
+	if err != nil {
+		log.Fatalf("compliance-report: %v", err)
+	}
+
+	batches, err := loadEvidence(*walDir, *coldGlob)
+	if err != nil {
+		log.Fatalf("compliance-report: %v", err)
+	}
+
+	analyzer := report.NewAnalyzer(*sample, nil)
+	rep, err := analyzer.Analyze(context.Background(), batches, window)
+	if err != nil {
+		log.Fatalf("compliance-report: analyzing evidence: %v", err)
+	}
+
+	if err := writeReport(rep, *out); err != nil {
+		log.Fatalf("compliance-report: %v", err)
+	}
+
+	log.Printf("wrote report.json, report.md, report.html, report.pdf to %s", *out)
+	log.Printf("%d controls covered, %d gaps", len(rep.Coverage), len(rep.Gaps))
+}
+
+// parseWindow parses the -since/-until flags into a report.Window; either
+// or both may be empty, leaving that bound unbounded.
+func parseWindow(since, until string) (report.Window, error) {
+	var w report.Window
+	if since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return w, fmt.Errorf("parsing -since %q: %w", since, err)
+		}
+		w.Start = t
+	}
+	if until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return w, fmt.Errorf("parsing -until %q: %w", until, err)
+		}
+		w.End = t
+	}
+	return w, nil
+}
+
+// loadEvidence reads every ResourceSpans batch from walDir's segments
+// and/or the cold archives matched by coldGlob.
+func loadEvidence(walDir, coldGlob string) ([]*tracepb.ResourceSpans, error) {
+	var batches []*tracepb.ResourceSpans
+
+	if walDir != "" {
+		wal, err := export.OpenWAL(walDir)
+		if err != nil {
+			return nil, fmt.Errorf("opening WAL at %s: %w", walDir, err)
+		}
+		defer wal.Close()
+
+		segments, err := wal.Segments()
+		if err != nil {
+			return nil, fmt.Errorf("listing WAL segments in %s: %w", walDir, err)
+		}
+		for _, name := range segments {
+			records, err := export.ReadSegment(wal.Path(name))
+			if err != nil {
+				return nil, fmt.Errorf("reading segment %s: %w", name, err)
+			}
+			batches = append(batches, records...)
+		}
+	}
+
+	if coldGlob != "" {
+		paths, err := filepath.Glob(coldGlob)
+		if err != nil {
+			return nil, fmt.Errorf("expanding -cold glob %s: %w", coldGlob, err)
+		}
+		for _, path := range paths {
+			records, err := export.ReadColdArchive(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading cold archive %s: %w", path, err)
+			}
+			batches = append(batches, records...)
+		}
+	}
+
+	return batches, nil
+}
+
+// writeReport renders rep in every supported format under outDir,
+// creating it if necessary.
+func writeReport(rep *report.Report, outDir string) error {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("creating output directory %s: %w", outDir, err)
+	}
+
+	data, err := rep.JSON()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "report.json"), data, 0o644); err != nil {
+		return fmt.Errorf("writing report.json: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outDir, "report.md"), []byte(rep.Markdown()), 0o644); err != nil {
+		return fmt.Errorf("writing report.md: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outDir, "report.html"), []byte(rep.HTML()), 0o644); err != nil {
+		return fmt.Errorf("writing report.html: %w", err)
+	}
+
+	pdfFile, err := os.Create(filepath.Join(outDir, "report.pdf"))
+	if err != nil {
+		return fmt.Errorf("creating report.pdf: %w", err)
+	}
+	defer pdfFile.Close()
+	if err := rep.WritePDF(pdfFile); err != nil {
+		return fmt.Errorf("writing report.pdf: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package attest
+
+import (
+	"context"
+	"fmt"
+)
+
+// LogExporter ships a signed envelope alongside the OTel spans that
+// describe the same evidence, e.g. via an OTLP log record, so the
+// attestation travels with the rest of the telemetry pipeline in addition
+// to (or instead of) a transparency log.
+type LogExporter interface {
+	ExportEnvelope(ctx context.Context, env Envelope) error
+}
+
+// SpanAttester produces a signed DSSE envelope for a compliance evidence
+// span and ships it to whichever sinks are configured. It's the piece that
+// GDPRSpan/SOC2Span call into via WithAttestation.
+type SpanAttester struct {
+	Signer Signer
+	// Rekor, if set, receives every envelope for transparency-log
+	// inclusion. Failures to upload are returned but do not invalidate
+	// the envelope itself.
+	Rekor RekorClient
+	// Logs, if set, receives every envelope as an OTLP log record.
+	Logs LogExporter
+}
+
+// NewSpanAttester returns a SpanAttester that signs with signer and ships
+// to whichever of rekor/logs are non-nil.
+func NewSpanAttester(signer Signer, rekor RekorClient, logs LogExporter) *SpanAttester {
+	return &SpanAttester{Signer: signer, Rekor: rekor, Logs: logs}
+}
+
+// Attest signs the statement built from e and ships it to the configured
+// sinks. A sink failure is returned (joined, if both fail) but the
+// envelope is still returned, since the signature itself is valid
+// regardless of whether shipping succeeded.
+func (a *SpanAttester) Attest(ctx context.Context, e Evidence) (Envelope, error) {
+	stmt := NewStatement(e)
+
+	env, err := a.Signer.Sign(ctx, stmt)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("attest: signing evidence statement: %w", err)
+	}
+
+	var shipErr error
+	if a.Rekor != nil {
+		if _, err := a.Rekor.Upload(ctx, env); err != nil {
+			shipErr = fmt.Errorf("attest: uploading to Rekor: %w", err)
+		}
+	}
+	if a.Logs != nil {
+		if err := a.Logs.ExportEnvelope(ctx, env); err != nil {
+			if shipErr != nil {
+				shipErr = fmt.Errorf("%w; attest: exporting envelope log: %v", shipErr, err)
+			} else {
+				shipErr = fmt.Errorf("attest: exporting envelope log: %w", err)
+			}
+		}
+	}
+
+	return env, shipErr
+}
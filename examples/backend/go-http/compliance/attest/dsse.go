@@ -0,0 +1,80 @@
+package attest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// PayloadType is the DSSE payload type for an in-toto statement.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE (Dead Simple Signing Envelope) wrapping a signed
+// in-toto Statement. See https://github.com/secure-systems-lab/dsse.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"` // base64-encoded Statement JSON
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is one signer's signature over an Envelope's payload.
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"` // base64-encoded
+}
+
+// Signer produces and verifies DSSE envelopes over compliance evidence
+// statements. Implementations in this package cover offline signing with a
+// local key (LocalSigner), delegated signing via a KMS (KMSSigner), and
+// keyless signing via a Fulcio-style OIDC flow (FulcioSigner).
+type Signer interface {
+	Sign(ctx context.Context, stmt Statement) (Envelope, error)
+	Verify(ctx context.Context, env Envelope) error
+}
+
+// PAE computes the DSSE pre-authentication encoding for payloadType and
+// payload, which is what gets signed rather than the raw payload. This
+// binds the payload type into the signature and prevents cross-protocol
+// confusion attacks.
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// digestPAE returns the SHA-256 digest of the PAE encoding, which is what
+// remote signers (KMS, HSM) are asked to sign rather than the raw payload.
+func digestPAE(payloadType string, payload []byte) []byte {
+	sum := sha256.Sum256(PAE(payloadType, payload))
+	return sum[:]
+}
+
+func encodeStatement(stmt Statement) ([]byte, error) {
+	payload, err := json.Marshal(stmt)
+	if err != nil {
+		return nil, fmt.Errorf("attest: marshaling statement: %w", err)
+	}
+	return payload, nil
+}
+
+func newEnvelope(payload []byte, keyID string, sig []byte) Envelope {
+	return Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}
+}
+
+// DecodeStatement base64-decodes and unmarshals the Statement carried by an
+// Envelope's payload, without verifying the signature.
+func DecodeStatement(env Envelope) (Statement, error) {
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return Statement{}, fmt.Errorf("attest: decoding payload: %w", err)
+	}
+	var stmt Statement
+	if err := json.Unmarshal(payload, &stmt); err != nil {
+		return Statement{}, fmt.Errorf("attest: unmarshaling statement: %w", err)
+	}
+	return stmt, nil
+}
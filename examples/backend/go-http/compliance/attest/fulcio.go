@@ -0,0 +1,136 @@
+package attest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// OIDCTokenSource returns a fresh OIDC identity token (e.g. from a
+// Kubernetes service account projected token, or an interactive browser
+// flow), which Fulcio exchanges for a short-lived signing certificate.
+type OIDCTokenSource func(ctx context.Context) (idToken string, err error)
+
+// FulcioConfig configures a keyless signing flow against a Fulcio-style
+// certificate authority: the signer mints an ephemeral keypair, proves
+// control of it plus an OIDC identity, and receives a short-lived
+// certificate binding the two, as in the sigstore/cosign keyless flow.
+type FulcioConfig struct {
+	// Issuer is the Fulcio-compatible CA's base URL, e.g.
+	// "https://fulcio.sigstore.dev".
+	Issuer string
+	// ClientID is the OAuth client ID the CA expects the OIDC token to
+	// have been issued for.
+	ClientID string
+	// Token supplies the OIDC identity token for each signing operation.
+	Token OIDCTokenSource
+	// HTTPClient defaults to http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// FulcioSigner signs DSSE envelopes with a fresh ephemeral key for every
+// Sign call, certified by a Fulcio-style CA rather than a long-lived key on
+// disk. This removes key management from the auditor's trust story: they
+// verify the certificate chain and the OIDC identity it was issued to,
+// not a key file.
+type FulcioSigner struct {
+	cfg FulcioConfig
+}
+
+// NewFulcioSigner returns a Signer that performs the keyless flow described
+// by cfg on every Sign call.
+func NewFulcioSigner(cfg FulcioConfig) *FulcioSigner {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &FulcioSigner{cfg: cfg}
+}
+
+type fulcioCertificateRequest struct {
+	PublicKey string `json:"publicKey"`
+	IDToken   string `json:"idToken"`
+	ClientID  string `json:"clientId"`
+}
+
+type fulcioCertificateResponse struct {
+	Certificate string `json:"certificate"` // PEM-encoded leaf certificate
+}
+
+// Sign implements Signer: it mints an ephemeral Ed25519 keypair, requests a
+// certificate for it from cfg.Issuer, then signs the statement with the
+// ephemeral key. The DSSE signature's keyid is the issued certificate
+// (PEM), so a verifier can check the cert chain and OIDC SAN without any
+// other lookup.
+func (s *FulcioSigner) Sign(ctx context.Context, stmt Statement) (Envelope, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("attest: generating ephemeral keypair: %w", err)
+	}
+
+	idToken, err := s.cfg.Token(ctx)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("attest: fetching OIDC token: %w", err)
+	}
+
+	cert, err := s.requestCertificate(ctx, pub, idToken)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	payload, err := encodeStatement(stmt)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	sig := ed25519.Sign(priv, PAE(PayloadType, payload))
+
+	return newEnvelope(payload, cert, sig), nil
+}
+
+// Verify implements Signer. Fulcio-issued certificates are short-lived
+// (minutes), so verification is expected to happen against the Rekor
+// transparency log entry (which timestamps the signature within the
+// certificate's validity window), not against the certificate alone; full
+// chain-of-trust verification is left to a dedicated verifier.
+func (s *FulcioSigner) Verify(ctx context.Context, env Envelope) error {
+	return fmt.Errorf("attest: FulcioSigner.Verify requires certificate-chain and Rekor inclusion-proof verification, not implemented here")
+}
+
+func (s *FulcioSigner) requestCertificate(ctx context.Context, pub ed25519.PublicKey, idToken string) (string, error) {
+	reqBody, err := json.Marshal(fulcioCertificateRequest{
+		PublicKey: base64.StdEncoding.EncodeToString(pub),
+		IDToken:   idToken,
+		ClientID:  s.cfg.ClientID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("attest: marshaling Fulcio request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Issuer+"/api/v2/signingCert", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("attest: building Fulcio request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("attest: calling Fulcio at %s: %w", s.cfg.Issuer, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("attest: Fulcio returned status %d", resp.StatusCode)
+	}
+
+	var certResp fulcioCertificateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&certResp); err != nil {
+		return "", fmt.Errorf("attest: decoding Fulcio response: %w", err)
+	}
+
+	return certResp.Certificate, nil
+}
@@ -0,0 +1,87 @@
+package attest
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+)
+
+// KMSKey is implemented by a thin per-provider adapter around an AWS KMS,
+// GCP KMS, or HashiCorp Vault transit key. Operators wire in the adapter
+// for whichever provider their `KMS_KEY_URI` points at; this package stays
+// free of any single cloud SDK dependency.
+type KMSKey interface {
+	// Public returns the key's public half, used to verify signatures
+	// offline without calling back out to the KMS.
+	Public(ctx context.Context) (crypto.PublicKey, error)
+	// Sign asks the KMS to sign digest (already hashed with the algorithm
+	// the key was provisioned with, typically SHA-256) and returns the
+	// raw signature bytes.
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// KMSURI is a parsed `awskms://`, `gcpkms://`, or `hashivault://` key
+// reference, as accepted by cosign-style tooling.
+type KMSURI struct {
+	Scheme   string // "awskms", "gcpkms", "hashivault"
+	Resource string // everything after "scheme://", with one leading "/" removed
+}
+
+// ParseKMSURI parses a KMS key reference such as
+// `awskms:///arn:aws:kms:us-east-1:123456789012:key/1234abcd-...`,
+// `gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k`, or
+// `hashivault://transit/keys/compliance-evidence`.
+func ParseKMSURI(uri string) (KMSURI, error) {
+	scheme, resource, ok := strings.Cut(uri, "://")
+	if !ok {
+		return KMSURI{}, fmt.Errorf("attest: invalid KMS URI %q: missing scheme", uri)
+	}
+
+	switch scheme {
+	case "awskms", "gcpkms", "hashivault":
+		return KMSURI{Scheme: scheme, Resource: strings.TrimPrefix(resource, "/")}, nil
+	default:
+		return KMSURI{}, fmt.Errorf("attest: unsupported KMS scheme %q", scheme)
+	}
+}
+
+// KMSSigner signs DSSE envelopes by delegating the actual signing
+// operation to a KMSKey, so the private key material never leaves the KMS.
+type KMSSigner struct {
+	keyID string
+	key   KMSKey
+}
+
+// NewKMSSigner returns a Signer backed by key, identified in the DSSE
+// signature's keyid field by keyID (typically the KMS URI itself).
+func NewKMSSigner(keyID string, key KMSKey) *KMSSigner {
+	return &KMSSigner{keyID: keyID, key: key}
+}
+
+// Sign implements Signer.
+func (s *KMSSigner) Sign(ctx context.Context, stmt Statement) (Envelope, error) {
+	payload, err := encodeStatement(stmt)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	digest := digestPAE(PayloadType, payload)
+	sig, err := s.key.Sign(ctx, digest)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("attest: KMS signing failed: %w", err)
+	}
+
+	return newEnvelope(payload, s.keyID, sig), nil
+}
+
+// Verify implements Signer by fetching the KMS key's public half and
+// checking the signature locally, so verification doesn't require a round
+// trip to the KMS on every audit.
+func (s *KMSSigner) Verify(ctx context.Context, env Envelope) error {
+	pub, err := s.key.Public(ctx)
+	if err != nil {
+		return fmt.Errorf("attest: fetching KMS public key: %w", err)
+	}
+	return verifyPAE(pub, env)
+}
@@ -0,0 +1,63 @@
+package attest
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLocalSignerRoundTrip(t *testing.T) {
+	_, priv, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	signer := NewLocalSigner("test-key", priv)
+
+	stmt := NewStatement(Evidence{
+		Framework:  "gdpr",
+		Control:    "Art.15",
+		Inputs:     map[string]interface{}{"userId": "123"},
+		Outputs:    map[string]interface{}{"recordsReturned": 1},
+		Result:     "success",
+		Duration:   5 * time.Millisecond,
+		TraceID:    "trace-1",
+		SpanID:     "span-1",
+		RecordedAt: time.Unix(0, 0),
+	})
+
+	env, err := signer.Sign(context.Background(), stmt)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if err := signer.Verify(context.Background(), env); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	got, err := DecodeStatement(env)
+	if err != nil {
+		t.Fatalf("DecodeStatement: %v", err)
+	}
+	if got.Predicate.Control != "Art.15" || got.PredicateType != PredicateType {
+		t.Fatalf("unexpected statement: %+v", got)
+	}
+}
+
+func TestLocalSignerRejectsTamperedPayload(t *testing.T) {
+	_, priv, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	signer := NewLocalSigner("test-key", priv)
+
+	env, err := signer.Sign(context.Background(), NewStatement(Evidence{Framework: "gdpr", Control: "Art.15"}))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	env.Payload = env.Payload[:len(env.Payload)-2] + "AA"
+
+	if err := signer.Verify(context.Background(), env); err == nil {
+		t.Fatal("expected verification to fail for a tampered payload")
+	}
+}
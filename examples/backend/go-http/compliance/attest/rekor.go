@@ -0,0 +1,95 @@
+package attest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// RekorClient ships signed envelopes to a Rekor-compatible transparency
+// log, so an auditor can later prove an attestation existed at a given
+// time without trusting whoever is currently storing it.
+type RekorClient interface {
+	// Upload submits env and returns the log entry's UUID.
+	Upload(ctx context.Context, env Envelope) (entryUUID string, err error)
+}
+
+// HTTPRekorClient is a RekorClient that talks to a Rekor REST API
+// (https://github.com/sigstore/rekor) over HTTP.
+type HTTPRekorClient struct {
+	// Endpoint is the Rekor server's base URL, e.g. "https://rekor.sigstore.dev".
+	Endpoint string
+	// HTTPClient defaults to http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// NewHTTPRekorClient returns a RekorClient for the given Rekor endpoint.
+func NewHTTPRekorClient(endpoint string) *HTTPRekorClient {
+	return &HTTPRekorClient{Endpoint: endpoint, HTTPClient: http.DefaultClient}
+}
+
+type rekorDSSEEntry struct {
+	Kind       string        `json:"kind"`
+	APIVersion string        `json:"apiVersion"`
+	Spec       rekorDSSESpec `json:"spec"`
+}
+
+type rekorDSSESpec struct {
+	ProposedContent rekorProposedContent `json:"proposedContent"`
+}
+
+type rekorProposedContent struct {
+	Envelope string `json:"envelope"`
+}
+
+type rekorUploadResponse struct {
+	UUID string `json:"uuid"`
+}
+
+// Upload implements RekorClient.
+func (c *HTTPRekorClient) Upload(ctx context.Context, env Envelope) (string, error) {
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	envJSON, err := json.Marshal(env)
+	if err != nil {
+		return "", fmt.Errorf("attest: marshaling envelope for Rekor: %w", err)
+	}
+
+	entry := rekorDSSEEntry{
+		Kind:       "dsse",
+		APIVersion: "0.0.1",
+		Spec:       rekorDSSESpec{ProposedContent: rekorProposedContent{Envelope: string(envJSON)}},
+	}
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("attest: marshaling Rekor entry: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint+"/api/v1/log/entries", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("attest: building Rekor request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("attest: calling Rekor at %s: %w", c.Endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("attest: Rekor returned status %d", resp.StatusCode)
+	}
+
+	var uploadResp rekorUploadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&uploadResp); err != nil {
+		return "", fmt.Errorf("attest: decoding Rekor response: %w", err)
+	}
+
+	return uploadResp.UUID, nil
+}
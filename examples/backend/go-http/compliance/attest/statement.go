@@ -0,0 +1,85 @@
+// Package attest produces signed in-toto attestations over compliance
+// evidence so auditors can verify the integrity of a GDPRSpan/SOC2Span's
+// recorded inputs, outputs, and decision independently of the OTel backend
+// that stores the span itself.
+package attest
+
+import "time"
+
+// PredicateType identifies the compliance evidence predicate schema. It
+// follows the in-toto convention of a stable, versioned URI.
+const PredicateType = "https://fluo.dev/compliance/evidence/v1"
+
+// StatementType is the in-toto v1 statement type.
+const StatementType = "https://in-toto.io/Statement/v1"
+
+// Subject identifies the evidence span this statement attests to.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Predicate is the compliance-evidence-specific payload of the statement:
+// everything an auditor needs to independently confirm what a GDPRSpan or
+// SOC2Span recorded.
+type Predicate struct {
+	Framework  string                 `json:"framework"`
+	Control    string                 `json:"control"`
+	Inputs     map[string]interface{} `json:"inputs"`
+	Outputs    map[string]interface{} `json:"outputs"`
+	Result     string                 `json:"result"`
+	DurationMs int64                  `json:"durationMs"`
+	TraceID    string                 `json:"traceId"`
+	SpanID     string                 `json:"spanId"`
+	Decision   string                 `json:"decision,omitempty"`
+	RecordedAt time.Time              `json:"recordedAt"`
+}
+
+// Statement is an in-toto v1 statement wrapping a compliance Predicate.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Evidence is the subset of a GDPRSpan/SOC2Span's state needed to build a
+// Statement. Callers in the compliance package populate this from the span
+// after it has recorded its result and policy decision.
+type Evidence struct {
+	Framework  string
+	Control    string
+	Inputs     map[string]interface{}
+	Outputs    map[string]interface{}
+	Result     string
+	Duration   time.Duration
+	TraceID    string
+	SpanID     string
+	Decision   string
+	RecordedAt time.Time
+}
+
+// NewStatement builds the in-toto statement for a piece of compliance
+// evidence. The subject digest is the span ID, since that's the stable
+// identifier an auditor will cross-reference against the OTel trace.
+func NewStatement(e Evidence) Statement {
+	return Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject: []Subject{
+			{Name: e.Framework + "." + e.Control, Digest: map[string]string{"spanId": e.SpanID}},
+		},
+		Predicate: Predicate{
+			Framework:  e.Framework,
+			Control:    e.Control,
+			Inputs:     e.Inputs,
+			Outputs:    e.Outputs,
+			Result:     e.Result,
+			DurationMs: e.Duration.Milliseconds(),
+			TraceID:    e.TraceID,
+			SpanID:     e.SpanID,
+			Decision:   e.Decision,
+			RecordedAt: e.RecordedAt,
+		},
+	}
+}
@@ -0,0 +1,345 @@
+package dsar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/attest"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/policy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultSLA is how long a data subject request has to be fulfilled
+// before it's overdue, per GDPR Art.12(3)'s one-month (extendable)
+// response window.
+const defaultSLA = 30 * 24 * time.Hour
+
+var orchestrationTracer = otel.Tracer("compliance-dsar")
+
+// control returns the GDPR article constant a Kind exercises.
+func (k Kind) control() string {
+	switch k {
+	case Access:
+		return compliance.Art_15
+	case Rectification:
+		return compliance.Art_16
+	case Erasure:
+		return compliance.Art_17
+	case Portability:
+		return compliance.Art_20
+	default:
+		return ""
+	}
+}
+
+// CoordinatorOption customizes a Coordinator at construction time.
+type CoordinatorOption func(*coordinatorConfig)
+
+type coordinatorConfig struct {
+	engine   policy.Engine
+	attester *attest.SpanAttester
+	verifier Verifier
+	sla      time.Duration
+}
+
+// WithPolicyEngine evaluates every per-source GDPR span this Coordinator
+// emits against engine.
+func WithPolicyEngine(engine policy.Engine) CoordinatorOption {
+	return func(c *coordinatorConfig) { c.engine = engine }
+}
+
+// WithAttestation signs every per-source GDPR span, and the aggregated
+// report, with attester.
+func WithAttestation(attester *attest.SpanAttester) CoordinatorOption {
+	return func(c *coordinatorConfig) { c.attester = attester }
+}
+
+// WithVerifier requires requesters to pass verifier's check before the
+// Coordinator fans a request out to any DataSource. Without one, requests
+// are processed unverified — fine for local development, not production.
+func WithVerifier(verifier Verifier) CoordinatorOption {
+	return func(c *coordinatorConfig) { c.verifier = verifier }
+}
+
+// WithSLA overrides the default 30-day response window.
+func WithSLA(d time.Duration) CoordinatorOption {
+	return func(c *coordinatorConfig) { c.sla = d }
+}
+
+// Coordinator is an http.Handler that turns a data subject request into a
+// GDPR-evidenced workflow: it verifies the requester, fans the request
+// out to every registered DataSource under one GDPRSpan per source
+// linked to a top-level orchestration span, aggregates the results into a
+// signed Report, and persists the request's lifecycle in a Ledger so its
+// SLA survives a restart.
+type Coordinator struct {
+	registry *Registry
+	ledger   Ledger
+	cfg      coordinatorConfig
+
+	requestSeq int64
+}
+
+// NewCoordinator returns a Coordinator fanning out to every DataSource in
+// registry and tracking request state in ledger. ledger may be nil, in
+// which case requests aren't persisted and SLA reminders can't run.
+func NewCoordinator(registry *Registry, ledger Ledger, opts ...CoordinatorOption) *Coordinator {
+	cfg := coordinatorConfig{sla: defaultSLA}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Coordinator{registry: registry, ledger: ledger, cfg: cfg}
+}
+
+// ServeHTTP dispatches to the access/rectification/erasure/portability
+// workflow named by the URL path's last segment, e.g. POST
+// /dsar/erasure?subject=123.
+func (c *Coordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kind := Kind(strings.TrimPrefix(r.URL.Path, "/dsar/"))
+	switch kind {
+	case Access, Rectification, Erasure, Portability:
+	default:
+		http.Error(w, fmt.Sprintf("dsar: unknown request kind %q", kind), http.StatusNotFound)
+		return
+	}
+
+	subjectID := r.URL.Query().Get("subject")
+	if subjectID == "" {
+		http.Error(w, "dsar: missing subject query parameter", http.StatusBadRequest)
+		return
+	}
+
+	report, err := c.Handle(r.Context(), kind, subjectID, r)
+	if err != nil {
+		http.Error(w, err.Error(), StatusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// Handle runs the full DSAR workflow for kind against subjectID: it
+// records a new Ledger entry, verifies the requester against r (if a
+// Verifier is configured), fans out to every DataSource under a
+// top-level orchestration span linked to each source's evidence span,
+// and records the outcome.
+func (c *Coordinator) Handle(ctx context.Context, kind Kind, subjectID string, r *http.Request) (Report, error) {
+	id := c.newRequestID(kind)
+	now := time.Now()
+	if c.ledger != nil {
+		if err := c.ledger.Create(ctx, Request{
+			ID:        id,
+			Kind:      kind,
+			SubjectID: subjectID,
+			Status:    StatusPending,
+			CreatedAt: now,
+			DueAt:     now.Add(c.cfg.sla),
+		}); err != nil {
+			return Report{}, fmt.Errorf("dsar: recording request %s: %w", id, err)
+		}
+	}
+
+	if err := c.verify(ctx, subjectID, r); err != nil {
+		c.fail(ctx, id, err)
+		return Report{}, err
+	}
+
+	ctx, orchestration := orchestrationTracer.Start(ctx, "dsar."+string(kind))
+	defer orchestration.End()
+	orchestration.SetAttributes(
+		attribute.String("compliance.dsar.request_id", id),
+		attribute.String("compliance.dsar.kind", string(kind)),
+		attribute.String("compliance.dsar.subject_id", subjectID),
+	)
+	link := trace.LinkFromContext(ctx)
+
+	report := Report{
+		RequestID: id,
+		Kind:      kind,
+		SubjectID: subjectID,
+		Generated: now,
+	}
+
+	for _, source := range c.registry.Sources() {
+		if err := c.runSource(ctx, kind, subjectID, source, &report, link); err != nil {
+			orchestration.RecordError(err)
+			c.fail(ctx, id, err)
+			return Report{}, err
+		}
+	}
+
+	signed, err := c.signReport(ctx, report)
+	if err != nil {
+		otel.Handle(err)
+		signed = nil
+	}
+	if c.ledger != nil {
+		if err := c.ledger.UpdateStatus(ctx, id, StatusCompleted, signed); err != nil {
+			otel.Handle(fmt.Errorf("dsar: recording completion of request %s: %w", id, err))
+		}
+	}
+
+	return report, nil
+}
+
+// runSource runs one DataSource's half of kind's workflow under its own
+// GDPRSpan, linked to the orchestration span via link, and folds the
+// result into report.
+func (c *Coordinator) runSource(ctx context.Context, kind Kind, subjectID string, source DataSource, report *Report, link trace.Link) error {
+	span := compliance.BeginGDPRSpan(ctx, kind.control(),
+		compliance.WithPolicyEngine(c.cfg.engine),
+		compliance.WithAttestation(c.cfg.attester),
+		compliance.WithLinks(link),
+	)
+	span.SetInput("subjectId", subjectID)
+	span.SetInput("source", source.Name())
+
+	switch kind {
+	case Access, Rectification:
+		records, err := source.Locate(ctx, subjectID)
+		if err != nil {
+			span.EndWithError(err)
+			return fmt.Errorf("dsar: locating records in %s: %w", source.Name(), err)
+		}
+		span.SetOutput("recordsFound", len(records))
+		report.Records = append(report.Records, records...)
+
+	case Portability:
+		var buf bytes.Buffer
+		if err := source.Export(ctx, subjectID, &buf); err != nil {
+			span.EndWithError(err)
+			return fmt.Errorf("dsar: exporting records from %s: %w", source.Name(), err)
+		}
+		span.SetOutput("bytesExported", buf.Len())
+		if report.Exports == nil {
+			report.Exports = make(map[string][]byte)
+		}
+		report.Exports[source.Name()] = buf.Bytes()
+
+	case Erasure:
+		erasure, err := source.Erase(ctx, subjectID)
+		if err != nil {
+			span.EndWithError(err)
+			return fmt.Errorf("dsar: erasing records in %s: %w", source.Name(), err)
+		}
+		span.SetOutput("recordsErased", erasure.RecordsErased)
+		report.Erasures = append(report.Erasures, erasure)
+	}
+
+	span.End()
+	return nil
+}
+
+// verify records the requester's identity check as a SOC 2 CC6.1 span. A
+// Coordinator with no configured Verifier processes requests unverified.
+func (c *Coordinator) verify(ctx context.Context, subjectID string, r *http.Request) error {
+	if c.cfg.verifier == nil {
+		return nil
+	}
+
+	span := compliance.BeginSOC2Span(ctx, compliance.CC6_1,
+		compliance.WithPolicyEngine(c.cfg.engine),
+		compliance.WithAttestation(c.cfg.attester),
+	)
+	span.SetInput("subjectId", subjectID)
+
+	method, err := c.cfg.verifier.Verify(ctx, subjectID, r)
+	if err != nil {
+		span.SetOutput("verified", false)
+		span.EndWithError(err)
+		return &VerificationError{Cause: fmt.Errorf("dsar: verification failed: %w", err)}
+	}
+
+	span.SetOutput("verified", true)
+	span.SetOutput("method", method)
+	span.End()
+	return nil
+}
+
+// signReport encodes rep as JSON and, if a SpanAttester is configured,
+// signs it with a DSSE envelope so an auditor can verify the report's
+// integrity independently of whatever is storing it.
+func (c *Coordinator) signReport(ctx context.Context, rep Report) ([]byte, error) {
+	data, err := rep.JSON()
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.attester == nil {
+		return data, nil
+	}
+
+	env, err := c.cfg.attester.Attest(ctx, attest.Evidence{
+		Framework:  "gdpr",
+		Control:    rep.Kind.control(),
+		Outputs:    map[string]interface{}{"report": string(data)},
+		Result:     "success",
+		RecordedAt: rep.Generated,
+	})
+	if err != nil {
+		return data, fmt.Errorf("dsar: signing report %s: %w", rep.RequestID, err)
+	}
+
+	envelope, err := json.Marshal(env)
+	if err != nil {
+		return data, fmt.Errorf("dsar: encoding signed envelope for report %s: %w", rep.RequestID, err)
+	}
+	return envelope, nil
+}
+
+// fail records a request as failed in the Ledger; failures to do so are
+// reported via otel.Handle rather than returned, since the caller is
+// already unwinding a different error.
+func (c *Coordinator) fail(ctx context.Context, id string, cause error) {
+	if c.ledger == nil {
+		return
+	}
+	if err := c.ledger.UpdateStatus(ctx, id, StatusFailed, nil); err != nil {
+		otel.Handle(fmt.Errorf("dsar: recording failure of request %s (caused by %w): %v", id, cause, err))
+	}
+}
+
+// RunReminders polls the Ledger every interval and calls notify for every
+// request that is neither completed nor failed and is at or past its SLA
+// due date, until ctx is cancelled. A typical
+// notify hook pages on-call or emails the DPO; the Coordinator only
+// tracks due dates, it doesn't send anything itself.
+func (c *Coordinator) RunReminders(ctx context.Context, interval time.Duration, notify func(Request)) error {
+	if c.ledger == nil {
+		return fmt.Errorf("dsar: reminders require a Ledger")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			due, err := c.ledger.Due(ctx, time.Now())
+			if err != nil {
+				otel.Handle(fmt.Errorf("dsar: listing due requests: %w", err))
+				continue
+			}
+			for _, req := range due {
+				notify(req)
+			}
+		}
+	}
+}
+
+// newRequestID returns a unique ID for a new request of the given kind.
+func (c *Coordinator) newRequestID(kind Kind) string {
+	n := atomic.AddInt64(&c.requestSeq, 1)
+	return fmt.Sprintf("dsar_%s_%d", kind, n)
+}
@@ -0,0 +1,206 @@
+package dsar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeSource is an in-memory DataSource used to exercise the Coordinator
+// without touching a real database.
+type fakeSource struct {
+	name    string
+	records map[string][]Record
+	erased  []string
+	located int
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Locate(ctx context.Context, subjectID string) ([]Record, error) {
+	f.located++
+	return f.records[subjectID], nil
+}
+
+func (f *fakeSource) Export(ctx context.Context, subjectID string, w io.Writer) error {
+	for _, rec := range f.records[subjectID] {
+		if _, err := w.Write([]byte(rec.Type)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeSource) Erase(ctx context.Context, subjectID string) (ErasureReport, error) {
+	n := len(f.records[subjectID])
+	delete(f.records, subjectID)
+	f.erased = append(f.erased, subjectID)
+	return ErasureReport{Source: f.name, RecordsErased: n, TablesCleared: []string{f.name}}, nil
+}
+
+func newFakeSource(name, subjectID string) *fakeSource {
+	return &fakeSource{
+		name: name,
+		records: map[string][]Record{
+			subjectID: {{Source: name, Type: "profile", Data: map[string]interface{}{"id": subjectID}}},
+		},
+	}
+}
+
+func TestCoordinatorHandleAccessAggregatesAllSources(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(newFakeSource("users-db", "sub-1"))
+	registry.Register(newFakeSource("orders-db", "sub-1"))
+
+	coord := NewCoordinator(registry, nil)
+	report, err := coord.Handle(context.Background(), Access, "sub-1", httptest.NewRequest(http.MethodGet, "/dsar/access?subject=sub-1", nil))
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if len(report.Records) != 2 {
+		t.Fatalf("expected 2 records aggregated across sources, got %d", len(report.Records))
+	}
+}
+
+func TestCoordinatorHandleErasureCallsEraseOnEverySource(t *testing.T) {
+	users := newFakeSource("users-db", "sub-1")
+	orders := newFakeSource("orders-db", "sub-1")
+	registry := NewRegistry()
+	registry.Register(users)
+	registry.Register(orders)
+
+	coord := NewCoordinator(registry, nil)
+	report, err := coord.Handle(context.Background(), Erasure, "sub-1", httptest.NewRequest(http.MethodDelete, "/dsar/erasure?subject=sub-1", nil))
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if len(report.Erasures) != 2 {
+		t.Fatalf("expected 2 erasure reports, got %d", len(report.Erasures))
+	}
+	if len(users.erased) != 1 || len(orders.erased) != 1 {
+		t.Fatal("expected Erase to be called on every registered source")
+	}
+}
+
+func TestCoordinatorHandleVerificationFailureSkipsSources(t *testing.T) {
+	source := newFakeSource("users-db", "sub-1")
+	registry := NewRegistry()
+	registry.Register(source)
+
+	coord := NewCoordinator(registry, nil, WithVerifier(denyingVerifier{}))
+	_, err := coord.Handle(context.Background(), Access, "sub-1", httptest.NewRequest(http.MethodGet, "/dsar/access?subject=sub-1", nil))
+	if err == nil {
+		t.Fatal("expected verification failure to be returned")
+	}
+	if source.located != 0 {
+		t.Fatalf("expected no DataSource to be reached after a failed verification, Locate was called %d time(s)", source.located)
+	}
+}
+
+type denyingVerifier struct{}
+
+func (denyingVerifier) Verify(ctx context.Context, subjectID string, r *http.Request) (string, error) {
+	return "", fmt.Errorf("dsar: requester denied")
+}
+
+func TestCoordinatorServeHTTPRejectsUnknownKind(t *testing.T) {
+	coord := NewCoordinator(NewRegistry(), nil)
+
+	rec := httptest.NewRecorder()
+	coord.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dsar/unknown?subject=sub-1", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown kind, got %d", rec.Code)
+	}
+}
+
+func TestReportJSONAndCSVRoundTrip(t *testing.T) {
+	report := Report{
+		RequestID: "dsar_access_1",
+		Kind:      Access,
+		SubjectID: "sub-1",
+		Generated: time.Now(),
+		Records: []Record{
+			{Source: "users-db", Type: "profile", Data: map[string]interface{}{"email": "alice@example.com"}},
+		},
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if !bytes.Contains(data, []byte("alice@example.com")) {
+		t.Fatal("expected JSON report to contain the record's data")
+	}
+
+	csvData, err := report.CSV()
+	if err != nil {
+		t.Fatalf("CSV: %v", err)
+	}
+	if !bytes.Contains(csvData, []byte("alice@example.com")) {
+		t.Fatal("expected CSV report to contain the record's data")
+	}
+}
+
+func TestSQLiteLedgerPersistsRequestState(t *testing.T) {
+	dir := t.TempDir()
+	ledger, err := NewSQLiteLedger(filepath.Join(dir, "dsar.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteLedger: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	req := Request{
+		ID:        "dsar_access_1",
+		Kind:      Access,
+		SubjectID: "sub-1",
+		Status:    StatusPending,
+		CreatedAt: now,
+		DueAt:     now, // already due, so the SLA reminder loop picks it up below
+	}
+	if err := ledger.Create(ctx, req); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := ledger.Get(ctx, req.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != StatusPending || got.SubjectID != req.SubjectID {
+		t.Fatalf("Get: got %+v, want a pending request for %s", got, req.SubjectID)
+	}
+
+	due, err := ledger.Due(ctx, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != req.ID {
+		t.Fatalf("Due: got %+v, want the pending request to be due", due)
+	}
+
+	if err := ledger.UpdateStatus(ctx, req.ID, StatusCompleted, []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	completed, err := ledger.Get(ctx, req.ID)
+	if err != nil {
+		t.Fatalf("Get after completion: %v", err)
+	}
+	if completed.Status != StatusCompleted || completed.CompletedAt.IsZero() {
+		t.Fatalf("Get after completion: got %+v, want a completed request with CompletedAt set", completed)
+	}
+
+	due, err = ledger.Due(ctx, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Due after completion: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("Due after completion: got %+v, want a completed request to no longer be due", due)
+	}
+}
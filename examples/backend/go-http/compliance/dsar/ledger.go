@@ -0,0 +1,40 @@
+package dsar
+
+import (
+	"context"
+	"time"
+)
+
+// Status is a DSAR request's lifecycle state.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusVerified  Status = "verified"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Request is one data-subject request as persisted by a Ledger.
+type Request struct {
+	ID          string
+	Kind        Kind
+	SubjectID   string
+	Status      Status
+	CreatedAt   time.Time
+	DueAt       time.Time
+	CompletedAt time.Time
+	Report      []byte // signed report, once completed
+}
+
+// Ledger persists DSAR request state so a restarted Coordinator can resume
+// tracking SLAs and an operator can audit what happened to a given
+// request, even across a crash.
+type Ledger interface {
+	Create(ctx context.Context, req Request) error
+	Get(ctx context.Context, id string) (Request, error)
+	UpdateStatus(ctx context.Context, id string, status Status, report []byte) error
+	// Due returns every request that is neither completed nor failed and
+	// whose DueAt is at or before before, for the SLA reminder loop.
+	Due(ctx context.Context, before time.Time) ([]Request, error)
+}
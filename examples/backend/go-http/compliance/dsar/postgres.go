@@ -0,0 +1,13 @@
+package dsar
+
+import (
+	"fmt"
+
+	_ "github.com/lib/pq" // Postgres driver
+)
+
+// NewPostgresLedger opens a Postgres-backed Ledger at dsn, for
+// multi-instance deployments that need a shared, durable request ledger.
+func NewPostgresLedger(dsn string) (Ledger, error) {
+	return openSQLLedger("postgres", dsn, func(n int) string { return fmt.Sprintf("$%d", n) })
+}
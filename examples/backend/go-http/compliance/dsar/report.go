@@ -0,0 +1,63 @@
+package dsar
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Report is the aggregated result of a DSAR fanned out across every
+// registered DataSource, ready to hand back to the subject (access,
+// portability) or to file as evidence that an erasure or rectification
+// completed.
+type Report struct {
+	RequestID string            `json:"requestId"`
+	Kind      Kind              `json:"kind"`
+	SubjectID string            `json:"subjectId"`
+	Generated time.Time         `json:"generatedAt"`
+	Records   []Record          `json:"records,omitempty"`
+	Erasures  []ErasureReport   `json:"erasures,omitempty"`
+	Exports   map[string][]byte `json:"exports,omitempty"` // source name -> portable export, Art.20 only
+}
+
+// JSON encodes the report as indented JSON.
+func (rep Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("dsar: encoding report %s as JSON: %w", rep.RequestID, err)
+	}
+	return data, nil
+}
+
+// CSV encodes the report's records and erasures as CSV, one row per fact
+// with the Record's Data fields flattened into source/type/key/value rows.
+// Coordinator.ServeHTTP does not serve it; it responds with JSON only.
+func (rep Report) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"source", "type", "key", "value"}); err != nil {
+		return nil, fmt.Errorf("dsar: writing CSV header for report %s: %w", rep.RequestID, err)
+	}
+	for _, rec := range rep.Records {
+		for k, v := range rec.Data {
+			if err := w.Write([]string{rec.Source, rec.Type, k, fmt.Sprintf("%v", v)}); err != nil {
+				return nil, fmt.Errorf("dsar: writing CSV row for report %s: %w", rep.RequestID, err)
+			}
+		}
+	}
+	for _, er := range rep.Erasures {
+		if err := w.Write([]string{er.Source, "erasure", "recordsErased", strconv.Itoa(er.RecordsErased)}); err != nil {
+			return nil, fmt.Errorf("dsar: writing CSV erasure row for report %s: %w", rep.RequestID, err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("dsar: flushing CSV report %s: %w", rep.RequestID, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,131 @@
+package dsar
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// createRequestsTable uses only column types both backends accept, so it
+// runs unmodified against SQLite and Postgres (SQLite stores the BYTEA
+// column's values as blobs).
+const createRequestsTable = `
+CREATE TABLE IF NOT EXISTS dsar_requests (
+	id           TEXT PRIMARY KEY,
+	kind         TEXT NOT NULL,
+	subject_id   TEXT NOT NULL,
+	status       TEXT NOT NULL,
+	created_at   TIMESTAMP NOT NULL,
+	due_at       TIMESTAMP NOT NULL,
+	completed_at TIMESTAMP,
+	report       BYTEA
+)`
+
+// sqlLedger is a Ledger backed by database/sql, shared between the SQLite
+// and Postgres backends; only the driver name, DSN, and bind-placeholder
+// syntax differ between them.
+type sqlLedger struct {
+	db          *sql.DB
+	placeholder func(n int) string // returns the nth (1-based) bind placeholder
+}
+
+func openSQLLedger(driverName, dsn string, placeholder func(int) string) (*sqlLedger, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("dsar: opening %s ledger: %w", driverName, err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("dsar: connecting to %s ledger: %w", driverName, err)
+	}
+	if _, err := db.Exec(createRequestsTable); err != nil {
+		return nil, fmt.Errorf("dsar: creating requests table: %w", err)
+	}
+	return &sqlLedger{db: db, placeholder: placeholder}, nil
+}
+
+func (l *sqlLedger) Create(ctx context.Context, req Request) error {
+	q := fmt.Sprintf(
+		"INSERT INTO dsar_requests (id, kind, subject_id, status, created_at, due_at) VALUES (%s, %s, %s, %s, %s, %s)",
+		l.placeholder(1), l.placeholder(2), l.placeholder(3), l.placeholder(4), l.placeholder(5), l.placeholder(6),
+	)
+	if _, err := l.db.ExecContext(ctx, q, req.ID, string(req.Kind), req.SubjectID, string(req.Status), req.CreatedAt, req.DueAt); err != nil {
+		return fmt.Errorf("dsar: recording request %s: %w", req.ID, err)
+	}
+	return nil
+}
+
+func (l *sqlLedger) Get(ctx context.Context, id string) (Request, error) {
+	q := fmt.Sprintf(
+		"SELECT id, kind, subject_id, status, created_at, due_at, completed_at, report FROM dsar_requests WHERE id = %s",
+		l.placeholder(1),
+	)
+	req, err := scanRequest(l.db.QueryRowContext(ctx, q, id).Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Request{}, fmt.Errorf("dsar: request %s not found", id)
+		}
+		return Request{}, fmt.Errorf("dsar: reading request %s: %w", id, err)
+	}
+	return req, nil
+}
+
+func (l *sqlLedger) UpdateStatus(ctx context.Context, id string, status Status, report []byte) error {
+	var completedAt interface{}
+	if status == StatusCompleted || status == StatusFailed {
+		completedAt = time.Now()
+	}
+	q := fmt.Sprintf(
+		"UPDATE dsar_requests SET status = %s, completed_at = %s, report = %s WHERE id = %s",
+		l.placeholder(1), l.placeholder(2), l.placeholder(3), l.placeholder(4),
+	)
+	if _, err := l.db.ExecContext(ctx, q, string(status), completedAt, report, id); err != nil {
+		return fmt.Errorf("dsar: updating request %s: %w", id, err)
+	}
+	return nil
+}
+
+func (l *sqlLedger) Due(ctx context.Context, before time.Time) ([]Request, error) {
+	q := fmt.Sprintf(
+		"SELECT id, kind, subject_id, status, created_at, due_at, completed_at, report FROM dsar_requests WHERE due_at <= %s AND status NOT IN (%s, %s)",
+		l.placeholder(1), l.placeholder(2), l.placeholder(3),
+	)
+	rows, err := l.db.QueryContext(ctx, q, before, string(StatusCompleted), string(StatusFailed))
+	if err != nil {
+		return nil, fmt.Errorf("dsar: listing due requests: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Request
+	for rows.Next() {
+		req, err := scanRequest(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("dsar: reading due request: %w", err)
+		}
+		out = append(out, req)
+	}
+	return out, rows.Err()
+}
+
+// scanRequest scans one requests row via scan (either a *sql.Row's or a
+// *sql.Rows's Scan method), handling the nullable completed_at/report
+// columns a request hasn't reached yet.
+func scanRequest(scan func(dest ...interface{}) error) (Request, error) {
+	var (
+		req         Request
+		kind        string
+		status      string
+		completedAt sql.NullTime
+		report      []byte
+	)
+	if err := scan(&req.ID, &kind, &req.SubjectID, &status, &req.CreatedAt, &req.DueAt, &completedAt, &report); err != nil {
+		return Request{}, err
+	}
+	req.Kind = Kind(kind)
+	req.Status = Status(status)
+	if completedAt.Valid {
+		req.CompletedAt = completedAt.Time
+	}
+	req.Report = report
+	return req, nil
+}
@@ -0,0 +1,11 @@
+package dsar
+
+import (
+	_ "modernc.org/sqlite" // pure-Go SQLite driver, no cgo
+)
+
+// NewSQLiteLedger opens (creating if necessary) a SQLite-backed Ledger at
+// path, suitable for a single-instance deployment of the example service.
+func NewSQLiteLedger(path string) (Ledger, error) {
+	return openSQLLedger("sqlite", path, func(int) string { return "?" })
+}
@@ -0,0 +1,81 @@
+// Package dsar turns the ad-hoc per-endpoint GDPR handling in
+// examples/go-http into a first-class data-subject request workflow: a
+// Coordinator fans a single request out to every registered DataSource,
+// aggregates the results into a signed Report, and tracks each request's
+// SLA in a Ledger that survives restarts.
+package dsar
+
+import (
+	"context"
+	"io"
+)
+
+// Record is one fact about a data subject found by a DataSource, ready to
+// be included verbatim in an access or rectification report.
+type Record struct {
+	Source string                 `json:"source"`
+	Type   string                 `json:"type"`
+	Data   map[string]interface{} `json:"data"`
+}
+
+// ErasureReport summarizes what a single DataSource erased in response to
+// a Right to Erasure (Art.17) request.
+type ErasureReport struct {
+	Source        string   `json:"source"`
+	RecordsErased int      `json:"recordsErased"`
+	TablesCleared []string `json:"tablesCleared"`
+}
+
+// DataSource is implemented by anything holding data about a subject that
+// a DSAR needs to reach: a database table, a search index, a third-party
+// API. The in-memory user store in examples/go-http's main package is the
+// simplest possible example.
+type DataSource interface {
+	// Name identifies the source in reports and evidence spans, e.g.
+	// "users-db".
+	Name() string
+	// Locate returns every record this source holds about subjectID. An
+	// empty, nil-error result means the source has nothing on the subject.
+	Locate(ctx context.Context, subjectID string) ([]Record, error)
+	// Export writes a portable encoding of subjectID's records to w, for
+	// Art.20 data portability requests.
+	Export(ctx context.Context, subjectID string, w io.Writer) error
+	// Erase deletes every record this source holds about subjectID.
+	Erase(ctx context.Context, subjectID string) (ErasureReport, error)
+}
+
+// Registry holds the DataSources a Coordinator fans a request out to.
+// Sources are registered once at startup, so Registry does not guard
+// against concurrent Register/Sources calls.
+type Registry struct {
+	sources []DataSource
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds source to the registry. Sources are fanned out to in
+// registration order.
+func (r *Registry) Register(source DataSource) {
+	r.sources = append(r.sources, source)
+}
+
+// Sources returns every registered DataSource, in registration order.
+func (r *Registry) Sources() []DataSource {
+	out := make([]DataSource, len(r.sources))
+	copy(out, r.sources)
+	return out
+}
+
+// Kind identifies which DSAR workflow a request is: the GDPR article it
+// exercises and the path segment a Coordinator mounts it at.
+type Kind string
+
+const (
+	Access        Kind = "access"        // Art.15
+	Rectification Kind = "rectification" // Art.16
+	Erasure       Kind = "erasure"       // Art.17
+	Portability   Kind = "portability"   // Art.20
+)
@@ -0,0 +1,39 @@
+package dsar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Verifier confirms a DSAR requester is who they claim to be before the
+// Coordinator fans out to any DataSource. The Coordinator records its
+// outcome as a SOC 2 CC6.1 evidence span; a Verifier implementation only
+// needs to do the actual check and report the method used.
+type Verifier interface {
+	// Verify checks r's proof of identity for subjectID and returns the
+	// method used (e.g. "email-otp", "oidc-reauth") on success.
+	Verify(ctx context.Context, subjectID string, r *http.Request) (method string, err error)
+}
+
+// VerificationError wraps a Verifier failure so StatusFor can tell it
+// apart from a DataSource or Ledger failure and report it as a 403
+// rather than a 500.
+type VerificationError struct {
+	Cause error
+}
+
+func (e *VerificationError) Error() string { return e.Cause.Error() }
+func (e *VerificationError) Unwrap() error { return e.Cause }
+
+// StatusFor maps an error returned by Coordinator.Handle to the HTTP
+// status code ServeHTTP should respond with: 403 for a failed
+// VerificationError, 500 for everything else (a DataSource or Ledger
+// failure).
+func StatusFor(err error) int {
+	var verr *VerificationError
+	if errors.As(err, &verr) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,281 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultPumpInterval is how often the background pump looks for
+// unacknowledged WAL records to ship.
+const defaultPumpInterval = 5 * time.Second
+
+// Option customizes a DurableTracerProvider at construction time.
+type Option func(*providerConfig)
+
+type providerConfig struct {
+	maxSegmentBytes int64
+	maxSegmentAge   time.Duration
+	pumpInterval    time.Duration
+	insecure        bool
+	compactAfter    time.Duration
+	coldDir         string
+	registerer      prometheus.Registerer
+}
+
+// WithMaxSegmentBytes overrides the WAL's default segment size rotation
+// threshold.
+func WithMaxSegmentBytes(n int64) Option {
+	return func(c *providerConfig) { c.maxSegmentBytes = n }
+}
+
+// WithMaxSegmentAge overrides the WAL's default segment age rotation
+// threshold.
+func WithMaxSegmentAge(d time.Duration) Option {
+	return func(c *providerConfig) { c.maxSegmentAge = d }
+}
+
+// WithPumpInterval sets how often the background pump checks for
+// unacknowledged records. Defaults to 5s.
+func WithPumpInterval(d time.Duration) Option {
+	return func(c *providerConfig) { c.pumpInterval = d }
+}
+
+// WithInsecure disables TLS on the connection to the OTLP endpoint, for
+// talking to a local or in-cluster collector over plaintext.
+func WithInsecure() Option {
+	return func(c *providerConfig) { c.insecure = true }
+}
+
+// WithCompaction enables background compaction: every pump interval, WAL
+// segments whose records are all acknowledged and which are older than
+// olderThan are merged into a gzip-compressed archive under dir/cold
+// (or under coldDir, if non-empty) instead of being deleted outright.
+func WithCompaction(olderThan time.Duration, coldDir string) Option {
+	return func(c *providerConfig) {
+		c.compactAfter = olderThan
+		c.coldDir = coldDir
+	}
+}
+
+// WithRegisterer registers the pump's Prometheus metrics with reg instead
+// of the default registry.
+func WithRegisterer(reg prometheus.Registerer) Option {
+	return func(c *providerConfig) { c.registerer = reg }
+}
+
+// DurableTracerProvider is an sdktrace.TracerProvider whose spans are
+// durably buffered: End() blocks until the span's OTLP encoding is
+// fsynced to a local WAL, and a background pump retries delivery to the
+// OTLP endpoint until each batch is acknowledged, so exporter outages
+// delay evidence delivery instead of dropping it.
+type DurableTracerProvider struct {
+	*sdktrace.TracerProvider
+
+	wal     *WAL
+	acks    *ackTracker
+	metrics *Metrics
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewDurableTracerProvider opens (or resumes) a WAL rooted at dir and
+// returns a TracerProvider that writes finished spans there before
+// forwarding them, in the background, to otlpEndpoint. Callers typically
+// register it with otel.SetTracerProvider.
+func NewDurableTracerProvider(dir, otlpEndpoint string, opts ...Option) (*DurableTracerProvider, error) {
+	cfg := &providerConfig{
+		maxSegmentBytes: defaultMaxSegmentBytes,
+		maxSegmentAge:   defaultMaxSegmentAge,
+		pumpInterval:    defaultPumpInterval,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	wal, err := OpenWAL(dir)
+	if err != nil {
+		return nil, err
+	}
+	wal.MaxSegmentBytes = cfg.maxSegmentBytes
+	wal.MaxSegmentAge = cfg.maxSegmentAge
+
+	acks, err := openAckTracker(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(otlpEndpoint)}
+	if cfg.insecure {
+		clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+	}
+	upload := otlptracehttp.NewClient(clientOpts...)
+
+	exporter, err := otlptrace.New(context.Background(), &walClient{wal: wal})
+	if err != nil {
+		return nil, fmt.Errorf("export: building OTLP exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &DurableTracerProvider{
+		TracerProvider: tp,
+		wal:            wal,
+		acks:           acks,
+		metrics:        newMetrics(cfg.registerer),
+		cancel:         cancel,
+		done:           make(chan struct{}),
+	}
+
+	go p.run(ctx, upload, cfg)
+
+	return p, nil
+}
+
+// walClient is an otlptrace.Client that appends every batch it's handed
+// to the WAL instead of sending it anywhere; it is the synchronous half
+// of the durable exporter, invoked from sdktrace.WithSyncer on the
+// goroutine that calls span.End().
+type walClient struct {
+	wal *WAL
+}
+
+func (c *walClient) Start(context.Context) error { return nil }
+func (c *walClient) Stop(context.Context) error  { return nil }
+
+func (c *walClient) UploadTraces(_ context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	for _, rs := range protoSpans {
+		if err := c.wal.Append(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run drives the background pump (and, if configured, compaction) until
+// ctx is cancelled.
+func (p *DurableTracerProvider) run(ctx context.Context, upload otlptrace.Client, cfg *providerConfig) {
+	defer close(p.done)
+
+	if err := upload.Start(ctx); err != nil {
+		// The pump retries on its own schedule below, so a failed
+		// initial connection attempt isn't fatal here.
+		_ = err
+	}
+	defer upload.Stop(context.Background())
+
+	ticker := time.NewTicker(cfg.pumpInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.pumpOnce(ctx, upload)
+			if cfg.compactAfter > 0 {
+				if _, _, err := Compact(p.wal.Dir, cfg.compactAfter, cfg.coldDir, p.acks, p.wal.currentSegment()); err != nil {
+					otel.Handle(fmt.Errorf("export: compaction: %w", err))
+				}
+			}
+		}
+	}
+}
+
+// pumpOnce ships every unacknowledged record in every segment, oldest
+// first, including the currently open one, and deletes segments other than
+// the open one once all of their records are acknowledged. It also
+// refreshes the queue-depth and oldest-unacked-age metrics from the records
+// this pass failed to ship.
+func (p *DurableTracerProvider) pumpOnce(ctx context.Context, upload otlptrace.Client) {
+	segments, err := p.wal.Segments()
+	if err != nil {
+		otel.Handle(fmt.Errorf("export: listing WAL segments: %w", err))
+		return
+	}
+
+	var (
+		queueDepth int
+		oldest     time.Time
+	)
+
+	for i, name := range segments {
+		isOpen := i == len(segments)-1 && p.wal.currentSegment() == name
+		path := p.wal.Path(name)
+
+		records, err := ReadSegment(path)
+		if err != nil {
+			otel.Handle(fmt.Errorf("export: reading segment %s: %w", name, err))
+			continue
+		}
+
+		acked := p.acks.AckedCount(name)
+		if acked > len(records) {
+			acked = len(records)
+		}
+		pending := records[acked:]
+
+		if len(pending) > 0 {
+			shipped := true
+			batch := Dedupe(pending, make(map[string]struct{}))
+			if len(batch) > 0 {
+				if err := upload.UploadTraces(ctx, batch); err != nil {
+					otel.Handle(fmt.Errorf("export: uploading segment %s: %w", name, err))
+					shipped = false
+				}
+			}
+			if shipped {
+				if err := p.acks.Ack(name, len(records)); err != nil {
+					otel.Handle(fmt.Errorf("export: acking segment %s: %w", name, err))
+					shipped = false
+				}
+			}
+			if !shipped {
+				queueDepth += len(pending)
+				if created, err := segmentCreatedAt(name); err == nil && (oldest.IsZero() || created.Before(oldest)) {
+					oldest = created
+				}
+				continue
+			}
+			acked = len(records)
+		}
+
+		if !isOpen && acked >= len(records) {
+			if err := p.wal.RemoveSegment(name); err != nil {
+				otel.Handle(fmt.Errorf("export: removing acked segment %s: %w", name, err))
+				continue
+			}
+			if err := p.acks.Forget(name); err != nil {
+				otel.Handle(fmt.Errorf("export: forgetting acked segment %s: %w", name, err))
+			}
+		}
+	}
+
+	p.metrics.QueueDepth.Set(float64(queueDepth))
+	if oldest.IsZero() {
+		p.metrics.OldestUnackedAge.Set(0)
+	} else {
+		p.metrics.OldestUnackedAge.Set(time.Since(oldest).Seconds())
+	}
+}
+
+// Shutdown flushes the underlying TracerProvider, stops the background
+// pump, and closes the WAL.
+func (p *DurableTracerProvider) Shutdown(ctx context.Context) error {
+	err := p.TracerProvider.Shutdown(ctx)
+	p.cancel()
+	<-p.done
+	if closeErr := p.wal.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
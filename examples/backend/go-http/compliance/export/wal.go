@@ -0,0 +1,246 @@
+// Package export provides a durable OTLP trace exporter: finished
+// compliance evidence spans are appended to a local write-ahead log before
+// End() returns, and a background pump ships them to an OTLP endpoint,
+// retrying until each batch is acknowledged. An exporter outage or a
+// process crash can delay evidence delivery, but it can't drop it.
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// segmentPrefix and segmentExt name WAL segment files as
+// "wal-<unix-nanos>.seg" so lexical order matches creation order.
+const (
+	segmentPrefix = "wal-"
+	segmentExt    = ".seg"
+)
+
+// recordMagic starts every record so a reader can tell a torn write (the
+// process crashed mid-fsync) from a corrupt one: a record whose length
+// prefix points past EOF is a torn trailing write and is dropped silently;
+// anything else that fails to unmarshal is a real corruption error.
+var recordMagic = [4]byte{'O', 'T', 'L', 'P'}
+
+// defaultMaxSegmentBytes and defaultMaxSegmentAge bound how large/old a
+// segment can get before the WAL rotates to a new one, unless overridden.
+const (
+	defaultMaxSegmentBytes = 8 << 20 // 8 MiB
+	defaultMaxSegmentAge   = time.Hour
+)
+
+// WAL is an append-only, segmented, fsynced log of OTLP ResourceSpans. It
+// is safe for concurrent use.
+type WAL struct {
+	Dir             string
+	MaxSegmentBytes int64
+	MaxSegmentAge   time.Duration
+
+	mu          sync.Mutex
+	file        *os.File
+	segmentPath string
+	segmentSize int64
+	segmentOpen time.Time
+}
+
+// OpenWAL opens (creating if necessary) a WAL rooted at dir.
+func OpenWAL(dir string) (*WAL, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("export: creating WAL dir %s: %w", dir, err)
+	}
+	return &WAL{
+		Dir:             dir,
+		MaxSegmentBytes: defaultMaxSegmentBytes,
+		MaxSegmentAge:   defaultMaxSegmentAge,
+	}, nil
+}
+
+// Append encodes spans as an OTLP ResourceSpans record, writes it to the
+// current segment, and fsyncs before returning, so a crash immediately
+// after Append returns can't lose the record.
+func (w *WAL) Append(spans *tracepb.ResourceSpans) error {
+	data, err := proto.Marshal(spans)
+	if err != nil {
+		return fmt.Errorf("export: marshaling spans: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.rotateIfNeededLocked(int64(len(data))); err != nil {
+		return err
+	}
+
+	var header [8]byte
+	copy(header[:4], recordMagic[:])
+	binary.BigEndian.PutUint32(header[4:], uint32(len(data)))
+
+	n1, err := w.file.Write(header[:])
+	if err != nil {
+		return fmt.Errorf("export: writing record header: %w", err)
+	}
+	n2, err := w.file.Write(data)
+	if err != nil {
+		return fmt.Errorf("export: writing record body: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("export: fsyncing %s: %w", w.segmentPath, err)
+	}
+
+	w.segmentSize += int64(n1 + n2)
+	return nil
+}
+
+func (w *WAL) rotateIfNeededLocked(nextRecordBytes int64) error {
+	needsRotate := w.file == nil ||
+		w.segmentSize+nextRecordBytes > w.MaxSegmentBytes ||
+		time.Since(w.segmentOpen) > w.MaxSegmentAge
+
+	if !needsRotate {
+		return nil
+	}
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return fmt.Errorf("export: closing segment %s: %w", w.segmentPath, err)
+		}
+	}
+
+	path := filepath.Join(w.Dir, fmt.Sprintf("%s%020d%s", segmentPrefix, time.Now().UnixNano(), segmentExt))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("export: creating segment %s: %w", path, err)
+	}
+
+	w.file = f
+	w.segmentPath = path
+	w.segmentSize = 0
+	w.segmentOpen = time.Now()
+	return nil
+}
+
+// Close flushes and closes the current segment, if any.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// Path returns name's path under the WAL's directory.
+func (w *WAL) Path(name string) string {
+	return filepath.Join(w.Dir, name)
+}
+
+// currentSegment returns the file name of the segment currently open for
+// writes, or "" if none is open yet.
+func (w *WAL) currentSegment() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return ""
+	}
+	return filepath.Base(w.segmentPath)
+}
+
+// RemoveSegment deletes the (fully acknowledged) segment name from disk.
+// It refuses to remove the segment currently open for writes.
+func (w *WAL) RemoveSegment(name string) error {
+	if name == w.currentSegment() {
+		return fmt.Errorf("export: refusing to remove open segment %s", name)
+	}
+	if err := os.Remove(w.Path(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("export: removing segment %s: %w", name, err)
+	}
+	return nil
+}
+
+// Segments returns the WAL's segment file names under Dir, oldest first.
+func (w *WAL) Segments() ([]string, error) {
+	entries, err := os.ReadDir(w.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("export: reading WAL dir %s: %w", w.Dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), segmentPrefix) || filepath.Ext(entry.Name()) != segmentExt {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ReadSegment reads every complete record from the segment at path,
+// skipping the trailing record if it was torn by a crash mid-write.
+func ReadSegment(path string) ([]*tracepb.ResourceSpans, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("export: opening segment %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var records []*tracepb.ResourceSpans
+	r := bufio.NewReader(f)
+
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF {
+				break
+			}
+			if err == io.ErrUnexpectedEOF {
+				break // torn header from a crash mid-write; stop here.
+			}
+			return nil, fmt.Errorf("export: reading record header in %s: %w", path, err)
+		}
+		if string(header[:4]) != string(recordMagic[:]) {
+			return nil, fmt.Errorf("export: corrupt segment %s: bad record magic", path)
+		}
+
+		length := binary.BigEndian.Uint32(header[4:])
+		data := make([]byte, length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break // torn body from a crash mid-write; stop here.
+			}
+			return nil, fmt.Errorf("export: reading record body in %s: %w", path, err)
+		}
+
+		var spans tracepb.ResourceSpans
+		if err := proto.Unmarshal(data, &spans); err != nil {
+			return nil, fmt.Errorf("export: unmarshaling record in %s: %w", path, err)
+		}
+		records = append(records, &spans)
+	}
+
+	return records, nil
+}
+
+// segmentCreatedAt parses the creation time encoded in a segment's file name.
+func segmentCreatedAt(name string) (time.Time, error) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentExt)
+	nanos, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("export: parsing segment name %s: %w", name, err)
+	}
+	return time.Unix(0, nanos), nil
+}
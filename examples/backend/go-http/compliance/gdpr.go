@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/attest"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/policy"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -12,24 +14,82 @@ import (
 
 // GDPR compliance controls
 const (
-	Art_15  = "Art.15"  // Right of Access
-	Art_17  = "Art.17"  // Right to Erasure
+	Art_15  = "Art.15"      // Right of Access
+	Art_16  = "Art.16"      // Right to Rectification
+	Art_17  = "Art.17"      // Right to Erasure
+	Art_20  = "Art.20"      // Right to Data Portability
 	Art_51f = "Art.5(1)(f)" // Security of Processing
-	Art_32  = "Art.32"  // Security of Processing
+	Art_32  = "Art.32"      // Security of Processing
 )
 
 var tracer = otel.Tracer("compliance-gdpr")
 
+func init() {
+	Register(Art_15, "Right of Access")
+	Register(Art_16, "Right to Rectification")
+	Register(Art_17, "Right to Erasure")
+	Register(Art_20, "Right to Data Portability")
+	Register(Art_51f, "Security of Processing")
+	Register(Art_32, "Security of Processing")
+}
+
 // GDPRSpan represents a compliance evidence span
 type GDPRSpan struct {
-	span  trace.Span
-	ctx   context.Context
-	start time.Time
+	span         trace.Span
+	ctx          context.Context
+	start        time.Time
+	control      string
+	engine       policy.Engine
+	inputs       map[string]interface{}
+	outputs      map[string]interface{}
+	decided      bool
+	lastDecision policy.Decision
+	attester     *attest.SpanAttester
+}
+
+// Option customizes a GDPRSpan or SOC2Span at creation time.
+type Option func(*spanConfig)
+
+type spanConfig struct {
+	engine   policy.Engine
+	attester *attest.SpanAttester
+	links    []trace.Link
+}
+
+// WithPolicyEngine evaluates the span's inputs/outputs against engine when the
+// span ends, recording the resulting allow/deny/warn decision as evidence.
+func WithPolicyEngine(engine policy.Engine) Option {
+	return func(c *spanConfig) {
+		c.engine = engine
+	}
+}
+
+// WithAttestation signs the span's evidence with attester when the span
+// ends, producing a DSSE envelope an auditor can verify independently of
+// whatever is storing the span itself.
+func WithAttestation(attester *attest.SpanAttester) Option {
+	return func(c *spanConfig) {
+		c.attester = attester
+	}
+}
+
+// WithLinks associates the span with other spans it doesn't causally
+// descend from, e.g. a per-source DSAR evidence span linking back to the
+// orchestration span that fanned the request out to it.
+func WithLinks(links ...trace.Link) Option {
+	return func(c *spanConfig) {
+		c.links = append(c.links, links...)
+	}
 }
 
 // BeginGDPRSpan starts a new GDPR evidence span
-func BeginGDPRSpan(ctx context.Context, control string) *GDPRSpan {
-	spanCtx, span := tracer.Start(ctx, "gdpr."+control)
+func BeginGDPRSpan(ctx context.Context, control string, opts ...Option) *GDPRSpan {
+	cfg := &spanConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	spanCtx, span := tracer.Start(ctx, "gdpr."+control, trace.WithLinks(cfg.links...))
 
 	span.SetAttributes(
 		attribute.String("compliance.framework", "gdpr"),
@@ -38,42 +98,136 @@ func BeginGDPRSpan(ctx context.Context, control string) *GDPRSpan {
 	)
 
 	return &GDPRSpan{
-		span:  span,
-		ctx:   spanCtx,
-		start: time.Now(),
+		span:     span,
+		ctx:      spanCtx,
+		start:    time.Now(),
+		control:  control,
+		engine:   cfg.engine,
+		inputs:   make(map[string]interface{}),
+		outputs:  make(map[string]interface{}),
+		attester: cfg.attester,
 	}
 }
 
 // SetInput adds an input attribute to the evidence span
 func (s *GDPRSpan) SetInput(key string, value interface{}) {
+	s.inputs[key] = value
 	s.setAttribute("input."+key, value)
 }
 
 // SetOutput adds an output attribute to the evidence span
 func (s *GDPRSpan) SetOutput(key string, value interface{}) {
+	s.outputs[key] = value
 	s.setAttribute("output."+key, value)
 }
 
+// SetIdentity records the caller's workload identity on the evidence span:
+// its SPIFFE ID, and whether it was established via a short-lived mTLS
+// certificate or a bearer token. Call it before End/EndWithError once the
+// caller's credentials have been inspected.
+func (s *GDPRSpan) SetIdentity(spiffeID, authMethod string) {
+	s.span.SetAttributes(
+		attribute.String("compliance.identity.spiffe_id", spiffeID),
+		attribute.String("compliance.identity.auth_method", authMethod),
+	)
+}
+
 // End completes the evidence span successfully
 func (s *GDPRSpan) End() {
+	s.Decide()
 	s.span.SetAttributes(
 		attribute.String("compliance.result", "success"),
 		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
 	)
+	s.attest("success")
 	s.span.End()
 }
 
 // EndWithError completes the evidence span with an error
 func (s *GDPRSpan) EndWithError(err error) {
+	s.Decide()
 	s.span.SetAttributes(
 		attribute.String("compliance.result", "failure"),
 		attribute.String("compliance.error", err.Error()),
 		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
 	)
 	s.span.RecordError(err)
+	s.attest("failure")
 	s.span.End()
 }
 
+// attest signs the collected evidence via the configured SpanAttester, if
+// any, and records whether signing (and shipping to its configured sinks)
+// succeeded. Attestation failures never fail the request the span is
+// covering; they're evidence metadata, not a compliance decision.
+func (s *GDPRSpan) attest(result string) {
+	if s.attester == nil {
+		return
+	}
+
+	sc := s.span.SpanContext()
+	_, err := s.attester.Attest(s.ctx, attest.Evidence{
+		Framework:  "gdpr",
+		Control:    s.control,
+		Inputs:     s.inputs,
+		Outputs:    s.outputs,
+		Result:     result,
+		Duration:   time.Since(s.start),
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		Decision:   string(s.lastDecision.Result),
+		RecordedAt: s.start,
+	})
+
+	s.span.SetAttributes(attribute.Bool("compliance.attestation.signed", err == nil))
+	if err != nil {
+		s.span.SetAttributes(attribute.String("compliance.attestation.error", err.Error()))
+	}
+}
+
+// Decide evaluates the configured policy engine against the evidence
+// collected so far and records the outcome as a compliance.decision
+// attribute, returning it so callers can act on it (e.g. an admission
+// controller denying a request) before the span ends. It is idempotent:
+// only the first call evaluates the engine, so callers that need the
+// decision to make a request-handling choice can call Decide explicitly and
+// End/EndWithError will not re-evaluate. Spans with no configured engine
+// always decide Allow.
+func (s *GDPRSpan) Decide() policy.Decision {
+	if s.decided {
+		return s.lastDecision
+	}
+	s.decided = true
+
+	if s.engine == nil {
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "no-engine"}
+		return s.lastDecision
+	}
+
+	decision, err := s.engine.Evaluate(s.ctx, policy.Input{
+		Framework: "gdpr",
+		Control:   s.control,
+		Inputs:    s.inputs,
+		Outputs:   s.outputs,
+	})
+	if err != nil {
+		s.span.SetAttributes(attribute.String("compliance.decision.error", err.Error()))
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "engine-error"}
+		return s.lastDecision
+	}
+
+	s.span.SetAttributes(
+		attribute.String("compliance.decision", string(decision.Result)),
+		attribute.String("compliance.decision.rule_id", decision.RuleID),
+	)
+	if decision.Reason != "" {
+		s.span.SetAttributes(attribute.String("compliance.decision.reason", decision.Reason))
+	}
+
+	s.lastDecision = decision
+	return decision
+}
+
 func (s *GDPRSpan) setAttribute(key string, value interface{}) {
 	switch v := value.(type) {
 	case string:
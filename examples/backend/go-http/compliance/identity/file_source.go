@@ -0,0 +1,164 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FileSource reads an SVID from disk in the layout written by common
+// SPIFFE sidecars (e.g. spiffe-helper, SPIRE's file-based rotation mode):
+// a leaf+intermediate certificate chain, a private key, and a trust bundle,
+// each PEM-encoded. It polls for changes and rotates in place, so it
+// doesn't need a SPIFFE Workload API socket to be available.
+type FileSource struct {
+	SVIDPath   string
+	KeyPath    string
+	BundlePath string
+
+	current atomic.Pointer[SVID]
+
+	mu       sync.Mutex
+	modTimes map[string]time.Time
+}
+
+// NewFileSource loads the initial SVID from svidPath/keyPath/bundlePath and
+// returns a FileSource ready to serve it. Call Watch to keep it current.
+func NewFileSource(svidPath, keyPath, bundlePath string) (*FileSource, error) {
+	s := &FileSource{SVIDPath: svidPath, KeyPath: keyPath, BundlePath: bundlePath, modTimes: make(map[string]time.Time)}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetX509SVID implements Source.
+func (s *FileSource) GetX509SVID() (*SVID, error) {
+	svid := s.current.Load()
+	if svid == nil {
+		return nil, fmt.Errorf("identity: no SVID loaded")
+	}
+	return svid, nil
+}
+
+// Watch polls the SVID/key/bundle files every interval and reloads when
+// any of their mtimes change, until ctx is done.
+func (s *FileSource) Watch(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			changed, err := s.filesChanged()
+			if err != nil {
+				return err
+			}
+			if changed {
+				if err := s.reload(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *FileSource) filesChanged() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, path := range []string{s.SVIDPath, s.KeyPath, s.BundlePath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, fmt.Errorf("identity: stat %s: %w", path, err)
+		}
+		if last, ok := s.modTimes[path]; !ok || info.ModTime().After(last) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *FileSource) reload() error {
+	svid, err := loadSVIDFromFiles(s.SVIDPath, s.KeyPath, s.BundlePath)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	for _, path := range []string{s.SVIDPath, s.KeyPath, s.BundlePath} {
+		if info, err := os.Stat(path); err == nil {
+			s.modTimes[path] = info.ModTime()
+		}
+	}
+	s.mu.Unlock()
+
+	s.current.Store(svid)
+	return nil
+}
+
+func loadSVIDFromFiles(svidPath, keyPath, bundlePath string) (*SVID, error) {
+	certChain, err := os.ReadFile(svidPath)
+	if err != nil {
+		return nil, fmt.Errorf("identity: reading SVID certificate %s: %w", filepath.Clean(svidPath), err)
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("identity: reading SVID key %s: %w", filepath.Clean(keyPath), err)
+	}
+	bundlePEM, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("identity: reading trust bundle %s: %w", filepath.Clean(bundlePath), err)
+	}
+
+	tlsCert, err := tls.X509KeyPair(certChain, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("identity: parsing SVID keypair: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("identity: parsing SVID leaf certificate: %w", err)
+	}
+	spiffeID, err := SPIFFEIDFromCertificate(leaf)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := x509.NewCertPool()
+	rest := bundlePEM
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("identity: parsing trust bundle certificate: %w", err)
+		}
+		bundle.AddCert(cert)
+	}
+
+	signer, ok := tlsCert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("identity: SVID private key does not implement crypto.Signer")
+	}
+
+	return &SVID{
+		ID:           spiffeID,
+		Certificates: tlsCert.Certificate,
+		PrivateKey:   signer,
+		TrustBundle:  bundle,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package identity
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// StepSource issues short-lived certificates from a Step/CFSSL-style CA
+// server and renews them automatically. It's the fallback path for
+// environments without a SPIFFE Workload API: the operator supplies a CA
+// URL and a one-time or long-lived provisioner token instead of relying on
+// node/workload attestation.
+type StepSource struct {
+	// CAURL is the base URL of the Step/CFSSL CA's sign endpoint, e.g.
+	// "https://ca.internal:9000".
+	CAURL string
+	// ProvisionerToken authenticates the signing request to the CA.
+	ProvisionerToken string
+	// SPIFFEID is the identity to request, e.g.
+	// "spiffe://example.org/ns/default/sa/compliance-admission".
+	SPIFFEID string
+	// RotationInterval is how often to request a new certificate. Step/CFSSL
+	// short-lived certs are typically valid for a multiple of this so a
+	// handful of missed renewals don't cause an outage.
+	RotationInterval time.Duration
+	// HTTPClient is used to call the CA; defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	current atomic.Pointer[SVID]
+}
+
+// NewStepSource requests an initial certificate from the CA and returns a
+// StepSource ready to serve it. Call Watch to keep it renewed.
+func NewStepSource(ctx context.Context, caURL, provisionerToken, spiffeID string, rotationInterval time.Duration) (*StepSource, error) {
+	s := &StepSource{
+		CAURL:            caURL,
+		ProvisionerToken: provisionerToken,
+		SPIFFEID:         spiffeID,
+		RotationInterval: rotationInterval,
+		HTTPClient:       http.DefaultClient,
+	}
+	if err := s.renew(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetX509SVID implements Source.
+func (s *StepSource) GetX509SVID() (*SVID, error) {
+	svid := s.current.Load()
+	if svid == nil {
+		return nil, fmt.Errorf("identity: no SVID issued yet")
+	}
+	return svid, nil
+}
+
+// Watch renews the certificate every RotationInterval until ctx is done.
+func (s *StepSource) Watch(ctx context.Context) error {
+	ticker := time.NewTicker(s.RotationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := s.renew(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *StepSource) renew(ctx context.Context) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("identity: generating key: %w", err)
+	}
+
+	svid, err := s.requestCertificate(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	s.current.Store(svid)
+	return nil
+}
+
+// stepSignRequest is the request body for the CA's sign endpoint.
+type stepSignRequest struct {
+	CSR   string `json:"csr"`
+	Token string `json:"ott"`
+}
+
+// stepSignResponse is the response body: a PEM certificate chain, leaf
+// first, and the trust bundle the client should use to verify peers.
+type stepSignResponse struct {
+	CertChainPEM string `json:"certChainPem"`
+	BundlePEM    string `json:"caBundlePem"`
+}
+
+func (s *StepSource) requestCertificate(ctx context.Context, key *ecdsa.PrivateKey) (*SVID, error) {
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: s.SPIFFEID},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("identity: creating CSR: %w", err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	reqBody, err := json.Marshal(stepSignRequest{CSR: string(csrPEM), Token: s.ProvisionerToken})
+	if err != nil {
+		return nil, fmt.Errorf("identity: encoding sign request: %w", err)
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.CAURL+"/1.0/sign", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("identity: building sign request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("identity: calling CA %s: %w", s.CAURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("identity: CA %s returned status %d", s.CAURL, resp.StatusCode)
+	}
+
+	var signResp stepSignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&signResp); err != nil {
+		return nil, fmt.Errorf("identity: decoding CA response: %w", err)
+	}
+
+	var certs [][]byte
+	rest := []byte(signResp.CertChainPEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		certs = append(certs, block.Bytes)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("identity: CA response contained no certificates")
+	}
+
+	leaf, err := x509.ParseCertificate(certs[0])
+	if err != nil {
+		return nil, fmt.Errorf("identity: parsing issued leaf certificate: %w", err)
+	}
+	spiffeID, err := SPIFFEIDFromCertificate(leaf)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := x509.NewCertPool()
+	rest = []byte(signResp.BundlePEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		caCert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("identity: parsing trust bundle certificate: %w", err)
+		}
+		bundle.AddCert(caCert)
+	}
+
+	return &SVID{
+		ID:           spiffeID,
+		Certificates: certs,
+		PrivateKey:   crypto.Signer(key),
+		TrustBundle:  bundle,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// BundleEngine is the default Engine: it holds one Bundle per
+// framework+control and evaluates whichever one matches the Input.
+// Bundles are swapped in atomically by a Loader, so BundleEngine is safe to
+// use concurrently with an in-flight reload.
+type BundleEngine struct {
+	mu      sync.RWMutex
+	bundles map[string]Bundle
+}
+
+// NewBundleEngine returns an Engine with no bundles loaded; every control
+// evaluates to the default allow decision until bundles are set via
+// SetBundle or a Loader.
+func NewBundleEngine() *BundleEngine {
+	return &BundleEngine{bundles: make(map[string]Bundle)}
+}
+
+// SetBundle installs or replaces the policy for bundle.Framework + bundle.Control.
+func (e *BundleEngine) SetBundle(bundle Bundle) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.bundles[bundleKey(bundle.Framework, bundle.Control)] = bundle
+}
+
+// Evaluate implements Engine.
+func (e *BundleEngine) Evaluate(ctx context.Context, in Input) (Decision, error) {
+	e.mu.RLock()
+	bundle, ok := e.bundles[bundleKey(in.Framework, in.Control)]
+	e.mu.RUnlock()
+	if !ok {
+		return Decision{Result: Allow, RuleID: "no-bundle", Reason: "no policy bundle loaded for this control"}, nil
+	}
+	return bundle.Evaluate(in), nil
+}
+
+// Loader reads policy bundles (one JSON document per file) from a directory
+// and installs them into a BundleEngine. The directory is typically either
+// a local path of hand-authored bundles, or the mount point of a Kubernetes
+// ConfigMap — both look the same on disk, so Loader doesn't need to know
+// which one it's pointed at. Watch polls the directory's mtimes and
+// hot-reloads whenever a bundle file changes, so operators can add or edit
+// controls without recompiling or restarting the service.
+type Loader struct {
+	Dir    string
+	Engine *BundleEngine
+
+	mu       sync.Mutex
+	modTimes map[string]time.Time
+}
+
+// NewLoader returns a Loader that reads JSON bundle files from dir into engine.
+func NewLoader(dir string, engine *BundleEngine) *Loader {
+	return &Loader{Dir: dir, Engine: engine, modTimes: make(map[string]time.Time)}
+}
+
+// Load reads every *.json file in l.Dir and installs it as a bundle. It is
+// safe to call repeatedly; each call re-reads the directory from scratch.
+func (l *Loader) Load() error {
+	entries, err := os.ReadDir(l.Dir)
+	if err != nil {
+		return fmt.Errorf("policy: reading bundle dir %s: %w", l.Dir, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(l.Dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("policy: stat %s: %w", path, err)
+		}
+
+		bundle, err := readBundle(path)
+		if err != nil {
+			return err
+		}
+
+		l.Engine.SetBundle(bundle)
+		l.modTimes[path] = info.ModTime()
+	}
+
+	return nil
+}
+
+// Watch polls l.Dir every interval, reloading only the bundle files whose
+// mtime has changed since the last poll, until ctx is done. It runs in the
+// calling goroutine; callers typically invoke it via `go loader.Watch(...)`.
+func (l *Loader) Watch(ctx context.Context, interval time.Duration) error {
+	if err := l.Load(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := l.reloadChanged(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (l *Loader) reloadChanged() error {
+	entries, err := os.ReadDir(l.Dir)
+	if err != nil {
+		return fmt.Errorf("policy: reading bundle dir %s: %w", l.Dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(l.Dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("policy: stat %s: %w", path, err)
+		}
+
+		l.mu.Lock()
+		last, seen := l.modTimes[path]
+		l.mu.Unlock()
+		if seen && !info.ModTime().After(last) {
+			continue
+		}
+
+		bundle, err := readBundle(path)
+		if err != nil {
+			return err
+		}
+
+		l.Engine.SetBundle(bundle)
+
+		l.mu.Lock()
+		l.modTimes[path] = info.ModTime()
+		l.mu.Unlock()
+	}
+
+	return nil
+}
+
+func readBundle(path string) (Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("policy: reading bundle %s: %w", path, err)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return Bundle{}, fmt.Errorf("policy: parsing bundle %s: %w", path, err)
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,99 @@
+// Package policy separates "what is compliant" (declarative rules loaded
+// from external bundles) from the evidence-recording responsibilities of the
+// compliance package. An Engine evaluates the inputs/outputs collected
+// on a compliance evidence span and returns an allow/deny/warn Decision that
+// callers can both act on and attach to the span as evidence.
+package policy
+
+import (
+	"context"
+	"fmt"
+)
+
+// Result is the outcome of evaluating a policy rule.
+type Result string
+
+const (
+	Allow Result = "allow"
+	Deny  Result = "deny"
+	Warn  Result = "warn"
+)
+
+// Input carries the evidence collected for a single control evaluation.
+type Input struct {
+	Framework string
+	Control   string
+	Inputs    map[string]interface{}
+	Outputs   map[string]interface{}
+}
+
+// Decision is the result of evaluating a policy bundle against an Input.
+type Decision struct {
+	Result Result
+	RuleID string
+	Reason string
+}
+
+// Engine evaluates declarative policy rules against compliance evidence.
+// The default implementation is the bundle-backed Engine in this package,
+// but CI environments with an OPA/Rego or CEL toolchain available can supply
+// their own Engine that compiles and evaluates those languages instead.
+type Engine interface {
+	Evaluate(ctx context.Context, in Input) (Decision, error)
+}
+
+// Rule is a single declarative check within a Bundle. When, if non-empty,
+// is matched against the merged "input."/"output." attribute namespace
+// produced from Input.Inputs and Input.Outputs; every key must be present
+// and equal for the rule to match. A Rule with an empty When always
+// matches, so bundles typically end with a catch-all default rule.
+type Rule struct {
+	ID     string                 `json:"id"`
+	When   map[string]interface{} `json:"when"`
+	Result Result                 `json:"result"`
+	Reason string                 `json:"reason"`
+}
+
+// Bundle is the declarative policy for one framework+control pair, as
+// loaded from a policy bundle file on disk (see Loader).
+type Bundle struct {
+	Framework string `json:"framework"`
+	Control   string `json:"control"`
+	Rules     []Rule `json:"rules"`
+}
+
+func bundleKey(framework, control string) string {
+	return framework + "/" + control
+}
+
+// Evaluate runs the bundle's rules in order and returns the first match.
+// A bundle with no matching rule (including an empty bundle) allows by
+// default, since the engine's job is to enforce declared rules, not to
+// invent new ones.
+func (b Bundle) Evaluate(in Input) Decision {
+	merged := make(map[string]interface{}, len(in.Inputs)+len(in.Outputs))
+	for k, v := range in.Inputs {
+		merged["input."+k] = v
+	}
+	for k, v := range in.Outputs {
+		merged["output."+k] = v
+	}
+
+	for _, rule := range b.Rules {
+		if ruleMatches(rule.When, merged) {
+			return Decision{Result: rule.Result, RuleID: rule.ID, Reason: rule.Reason}
+		}
+	}
+
+	return Decision{Result: Allow, RuleID: "default-allow", Reason: "no rule matched"}
+}
+
+func ruleMatches(when map[string]interface{}, attrs map[string]interface{}) bool {
+	for k, want := range when {
+		got, ok := attrs[k]
+		if !ok || fmt.Sprintf("%v", got) != fmt.Sprintf("%v", want) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package compliance
+
+import "sync"
+
+// ControlInfo describes a compliance control that GDPRSpan/SOC2Span
+// evidence can attest to. The compliance-report CLI enumerates these to
+// flag controls that produced zero evidence in a given window — a gap a
+// coverage matrix built only from the evidence itself could never see.
+type ControlInfo struct {
+	Control     string
+	Description string
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []ControlInfo
+	registered = make(map[string]bool)
+)
+
+// Register adds control to the package-level control registry, so report
+// generation can enumerate every control a framework declares even if it
+// never emitted evidence. Frameworks call this once per control from an
+// init() function; registering the same control twice is a no-op.
+func Register(control, description string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if registered[control] {
+		return
+	}
+	registered[control] = true
+	registry = append(registry, ControlInfo{Control: control, Description: description})
+}
+
+// Controls returns every registered control, in registration order.
+func Controls() []ControlInfo {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	out := make([]ControlInfo, len(registry))
+	copy(out, registry)
+	return out
+}
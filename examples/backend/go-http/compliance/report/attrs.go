@@ -0,0 +1,53 @@
+package report
+
+import commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+
+// stringAttr returns the string-valued attribute named key, or "" if
+// absent or not a string.
+func stringAttr(attrs []*commonpb.KeyValue, key string) string {
+	for _, kv := range attrs {
+		if kv.GetKey() == key {
+			return kv.GetValue().GetStringValue()
+		}
+	}
+	return ""
+}
+
+// boolAttr returns the bool-valued attribute named key.
+func boolAttr(attrs []*commonpb.KeyValue, key string) bool {
+	for _, kv := range attrs {
+		if kv.GetKey() == key {
+			return kv.GetValue().GetBoolValue()
+		}
+	}
+	return false
+}
+
+// intAttr returns the int-valued attribute named key.
+func intAttr(attrs []*commonpb.KeyValue, key string) int64 {
+	for _, kv := range attrs {
+		if kv.GetKey() == key {
+			return kv.GetValue().GetIntValue()
+		}
+	}
+	return 0
+}
+
+// anyValueToGo converts an OTLP AnyValue back to the Go value
+// GDPRSpan.setAttribute/SOC2Span.setAttribute encoded it from: a string,
+// bool, or int64. setAttribute writes no double values; one written by other
+// instrumentation comes back as a float64.
+func anyValueToGo(v *commonpb.AnyValue) interface{} {
+	switch val := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return val.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return val.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return val.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return val.DoubleValue
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package report
+
+import "sort"
+
+// buildCoverage aggregates events into one ControlCoverage per
+// framework+control pair, sorted by framework then control for a stable,
+// diffable report.
+func buildCoverage(events []evidenceEvent) []ControlCoverage {
+	type key struct{ framework, control string }
+	byControl := make(map[key]*ControlCoverage)
+	sources := make(map[key]map[string]bool)
+
+	for _, ev := range events {
+		k := key{ev.Framework, ev.Control}
+		cov, ok := byControl[k]
+		if !ok {
+			cov = &ControlCoverage{
+				Framework:   ev.Framework,
+				Control:     ev.Control,
+				Description: descriptionFor(ev.Control),
+				FirstSeen:   ev.RecordedAt,
+				LastSeen:    ev.RecordedAt,
+			}
+			byControl[k] = cov
+			sources[k] = make(map[string]bool)
+		}
+
+		if ev.Result == "failure" {
+			cov.Failures++
+		} else {
+			cov.Successes++
+		}
+		if ev.RecordedAt.Before(cov.FirstSeen) {
+			cov.FirstSeen = ev.RecordedAt
+		}
+		if ev.RecordedAt.After(cov.LastSeen) {
+			cov.LastSeen = ev.RecordedAt
+		}
+		sources[k][ev.Source] = true
+	}
+
+	out := make([]ControlCoverage, 0, len(byControl))
+	for k, cov := range byControl {
+		cov.Sources = sortedKeys(sources[k])
+		out = append(out, *cov)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Framework != out[j].Framework {
+			return out[i].Framework < out[j].Framework
+		}
+		return out[i].Control < out[j].Control
+	})
+	return out
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// evidenceEvent is one compliance evidence span, flattened out of its OTLP
+// representation into the fields the rest of this package needs.
+type evidenceEvent struct {
+	Framework  string
+	Control    string
+	Result     string
+	DurationMs int64
+	TraceID    string
+	SpanID     string
+	Source     string // instrumentation scope name, e.g. "compliance-gdpr"
+	RecordedAt time.Time
+	Inputs     map[string]interface{}
+	Outputs    map[string]interface{}
+	Attested   bool
+}
+
+// extractEvidence walks every ResourceSpans and returns the evidence
+// events found within it. Spans that don't carry BeginGDPRSpan/
+// BeginSOC2Span's compliance.type=evidence attribute — ordinary
+// application spans sharing the same trace — are silently skipped.
+func extractEvidence(batches []*tracepb.ResourceSpans) []evidenceEvent {
+	var out []evidenceEvent
+	for _, rs := range batches {
+		for _, ss := range rs.GetScopeSpans() {
+			source := ss.GetScope().GetName()
+			for _, span := range ss.GetSpans() {
+				if ev, ok := evidenceFromSpan(span, source); ok {
+					out = append(out, ev)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func evidenceFromSpan(span *tracepb.Span, source string) (evidenceEvent, bool) {
+	attrs := span.GetAttributes()
+	if stringAttr(attrs, "compliance.type") != "evidence" {
+		return evidenceEvent{}, false
+	}
+
+	ev := evidenceEvent{
+		Framework:  stringAttr(attrs, "compliance.framework"),
+		Control:    stringAttr(attrs, "compliance.control"),
+		Result:     stringAttr(attrs, "compliance.result"),
+		DurationMs: intAttr(attrs, "compliance.duration_ms"),
+		TraceID:    hex.EncodeToString(span.GetTraceId()),
+		SpanID:     hex.EncodeToString(span.GetSpanId()),
+		Source:     source,
+		RecordedAt: time.Unix(0, int64(span.GetStartTimeUnixNano())).UTC(),
+		Inputs:     make(map[string]interface{}),
+		Outputs:    make(map[string]interface{}),
+		Attested:   boolAttr(attrs, "compliance.attestation.signed"),
+	}
+
+	for _, kv := range attrs {
+		switch {
+		case strings.HasPrefix(kv.GetKey(), "input."):
+			ev.Inputs[strings.TrimPrefix(kv.GetKey(), "input.")] = anyValueToGo(kv.GetValue())
+		case strings.HasPrefix(kv.GetKey(), "output."):
+			ev.Outputs[strings.TrimPrefix(kv.GetKey(), "output.")] = anyValueToGo(kv.GetValue())
+		}
+	}
+
+	return ev, true
+}
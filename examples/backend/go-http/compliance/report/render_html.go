@@ -0,0 +1,66 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// HTML renders the report as a single static page — a control-coverage
+// matrix, gap report, and per-control sampled evidence — suitable for
+// uploading as-is to a Drata/Vanta-style evidence locker.
+func (r *Report) HTML() string {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\">\n")
+	b.WriteString("<title>Compliance Evidence Report</title>\n")
+	b.WriteString("<style>\n")
+	b.WriteString("body{font-family:sans-serif;margin:2rem;color:#1a1a1a}\n")
+	b.WriteString("table{border-collapse:collapse;width:100%;margin-bottom:2rem}\n")
+	b.WriteString("th,td{border:1px solid #ccc;padding:.4rem .6rem;text-align:left;font-size:.9rem}\n")
+	b.WriteString("th{background:#f0f0f0}\n")
+	b.WriteString(".gap{color:#a00}\n")
+	b.WriteString("</style></head><body>\n")
+
+	fmt.Fprintf(&b, "<h1>Compliance Evidence Report</h1>\n<p>Generated: %s</p>\n", html.EscapeString(r.GeneratedAt.Format("2006-01-02 15:04:05 UTC")))
+	if !r.Window.Start.IsZero() || !r.Window.End.IsZero() {
+		fmt.Fprintf(&b, "<p>Window: %s &mdash; %s</p>\n", html.EscapeString(formatWindowBound(r.Window.Start)), html.EscapeString(formatWindowBound(r.Window.End)))
+	}
+
+	b.WriteString("<h2>Control Coverage</h2>\n<table><tr><th>Framework</th><th>Control</th><th>Description</th><th>Success</th><th>Failure</th><th>First Seen</th><th>Last Seen</th><th>Sources</th></tr>\n")
+	for _, c := range r.Coverage {
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(c.Framework), html.EscapeString(c.Control), html.EscapeString(c.Description),
+			c.Successes, c.Failures, c.FirstSeen.Format("2006-01-02"), c.LastSeen.Format("2006-01-02"), html.EscapeString(strings.Join(c.Sources, ", ")))
+	}
+	b.WriteString("</table>\n")
+
+	b.WriteString("<h2>Gaps</h2>\n")
+	if len(r.Gaps) == 0 {
+		b.WriteString("<p>No gaps: every registered control produced evidence in this window.</p>\n")
+	} else {
+		b.WriteString("<table><tr><th>Framework</th><th>Control</th><th>Description</th></tr>\n")
+		for _, g := range r.Gaps {
+			fmt.Fprintf(&b, "<tr class=\"gap\"><td>%s</td><td>%s</td><td>%s</td></tr>\n", html.EscapeString(g.Framework), html.EscapeString(g.Control), html.EscapeString(g.Description))
+		}
+		b.WriteString("</table>\n")
+	}
+
+	b.WriteString("<h2>Sampled Evidence</h2>\n")
+	for _, c := range r.Coverage {
+		packets := r.Samples[c.Control]
+		if len(packets) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "<h3>%s</h3>\n<table><tr><th>Recorded At</th><th>Result</th><th>Trace</th><th>Attested</th></tr>\n", html.EscapeString(c.Control))
+		for _, p := range packets {
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s / %s</td><td>%t</td></tr>\n",
+				p.RecordedAt.Format("2006-01-02 15:04:05"), html.EscapeString(p.Result),
+				html.EscapeString(p.TraceID), html.EscapeString(p.SpanID), p.Attested)
+		}
+		b.WriteString("</table>\n")
+	}
+
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
@@ -0,0 +1,16 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON encodes the report as indented JSON, the machine-readable format a
+// downstream tool (a GRC platform import, a dashboard) consumes.
+func (r *Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("report: encoding report as JSON: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Markdown renders the control-coverage matrix and gap report as a
+// Markdown document, meant to be pasted into the PR or ticket that closes
+// out an audit period.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Compliance Evidence Report\n\n")
+	fmt.Fprintf(&b, "Generated: %s\n\n", r.GeneratedAt.Format("2006-01-02 15:04:05 UTC"))
+	if !r.Window.Start.IsZero() || !r.Window.End.IsZero() {
+		fmt.Fprintf(&b, "Window: %s — %s\n\n", formatWindowBound(r.Window.Start), formatWindowBound(r.Window.End))
+	}
+
+	fmt.Fprintf(&b, "## Control Coverage\n\n")
+	fmt.Fprintf(&b, "| Framework | Control | Description | Success | Failure | First Seen | Last Seen | Sources |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|---|---|---|\n")
+	for _, c := range r.Coverage {
+		fmt.Fprintf(&b, "| %s | %s | %s | %d | %d | %s | %s | %s |\n",
+			c.Framework, c.Control, c.Description, c.Successes, c.Failures,
+			c.FirstSeen.Format("2006-01-02"), c.LastSeen.Format("2006-01-02"), strings.Join(c.Sources, ", "))
+	}
+
+	fmt.Fprintf(&b, "\n## Gaps\n\n")
+	if len(r.Gaps) == 0 {
+		fmt.Fprintf(&b, "No gaps: every registered control produced evidence in this window.\n")
+	} else {
+		fmt.Fprintf(&b, "| Framework | Control | Description |\n|---|---|---|\n")
+		for _, g := range r.Gaps {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", g.Framework, g.Control, g.Description)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Sampled Evidence\n\n")
+	if len(r.Samples) == 0 {
+		fmt.Fprintf(&b, "No samples collected.\n")
+	} else {
+		for _, c := range r.Coverage {
+			packets := r.Samples[c.Control]
+			if len(packets) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "- **%s**: %d packet(s) sampled, see the JSON report for full inputs/outputs and attestations\n", c.Control, len(packets))
+		}
+	}
+
+	return b.String()
+}
+
+func formatWindowBound(t time.Time) string {
+	if t.IsZero() {
+		return "(unbounded)"
+	}
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
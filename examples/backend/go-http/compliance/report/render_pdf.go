@@ -0,0 +1,111 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// WritePDF renders one page per sampled evidence packet — inputs, outputs,
+// the trace it belongs to, and whether it was attested — to w. It's meant
+// for handing an auditor a self-contained packet alongside the JSON/HTML
+// report, without them needing query access to the trace backend.
+func (r *Report) WritePDF(w io.Writer) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Compliance Evidence Report", false)
+
+	for _, control := range sortedSampleControls(r.Samples) {
+		for _, packet := range r.Samples[control] {
+			writeEvidencePage(pdf, packet)
+		}
+	}
+
+	if pdf.Err() {
+		return fmt.Errorf("report: rendering PDF: %w", pdf.Error())
+	}
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("report: writing PDF: %w", err)
+	}
+	return nil
+}
+
+func writeEvidencePage(pdf *gofpdf.Fpdf, packet EvidencePacket) {
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, fmt.Sprintf("%s - %s", packet.Framework, packet.Control))
+	pdf.Ln(12)
+
+	pdf.SetFont("Arial", "", 11)
+	pdf.Cell(0, 7, fmt.Sprintf("Result: %s", packet.Result))
+	pdf.Ln(7)
+	pdf.Cell(0, 7, fmt.Sprintf("Recorded at: %s", packet.RecordedAt.Format("2006-01-02 15:04:05 UTC")))
+	pdf.Ln(7)
+	pdf.Cell(0, 7, fmt.Sprintf("Duration: %d ms", packet.DurationMs))
+	pdf.Ln(7)
+	pdf.Cell(0, 7, fmt.Sprintf("Source: %s", packet.Source))
+	pdf.Ln(7)
+	pdf.Cell(0, 7, fmt.Sprintf("Trace: %s / span %s", packet.TraceID, packet.SpanID))
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(0, 7, "Inputs")
+	pdf.Ln(7)
+	pdf.SetFont("Arial", "", 10)
+	writeFieldMap(pdf, packet.Inputs)
+
+	pdf.Ln(3)
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(0, 7, "Outputs")
+	pdf.Ln(7)
+	pdf.SetFont("Arial", "", 10)
+	writeFieldMap(pdf, packet.Outputs)
+
+	pdf.Ln(3)
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(0, 7, "Attestation")
+	pdf.Ln(7)
+	pdf.SetFont("Arial", "", 10)
+	if !packet.Attested {
+		pdf.Cell(0, 6, "not attested")
+		pdf.Ln(6)
+	} else if packet.Envelope == nil {
+		pdf.Cell(0, 6, "attested, envelope unavailable")
+		pdf.Ln(6)
+	} else {
+		pdf.Cell(0, 6, fmt.Sprintf("DSSE envelope, payload type %s", packet.Envelope.PayloadType))
+		pdf.Ln(6)
+		for _, sig := range packet.Envelope.Signatures {
+			pdf.Cell(0, 6, fmt.Sprintf("  signed by %s", sig.KeyID))
+			pdf.Ln(6)
+		}
+	}
+}
+
+func writeFieldMap(pdf *gofpdf.Fpdf, fields map[string]interface{}) {
+	if len(fields) == 0 {
+		pdf.Cell(0, 6, "(none)")
+		pdf.Ln(6)
+		return
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pdf.Cell(0, 6, fmt.Sprintf("  %s: %v", k, fields[k]))
+		pdf.Ln(6)
+	}
+}
+
+func sortedSampleControls(samples map[string][]EvidencePacket) []string {
+	out := make([]string, 0, len(samples))
+	for k := range samples {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
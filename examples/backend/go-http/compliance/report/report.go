@@ -0,0 +1,160 @@
+// Package report turns the evidence spans BeginGDPRSpan/BeginSOC2Span
+// produce into auditor-ready artifacts: a control-coverage matrix, a gap
+// report of controls that produced no evidence, and a sample of evidence
+// packets per control. It reads evidence from whatever already stores the
+// raw OTLP spans — a durable exporter's WAL (export.OpenWAL/ReadSegment)
+// or one of its cold archives (export.ReadColdArchive) — rather than
+// querying a backend directly, so the same analysis runs identically
+// whether the spans are still pending shipment or long since compacted.
+package report
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/attest"
+)
+
+// Window bounds the analysis to evidence recorded in [Start, End). A zero
+// Window (both fields unset) analyzes every event it's given.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func (w Window) contains(t time.Time) bool {
+	if !w.Start.IsZero() && t.Before(w.Start) {
+		return false
+	}
+	if !w.End.IsZero() && !t.Before(w.End) {
+		return false
+	}
+	return true
+}
+
+// ControlCoverage summarizes the evidence recorded for one framework
+// control within the analysis window.
+type ControlCoverage struct {
+	Framework   string    `json:"framework"`
+	Control     string    `json:"control"`
+	Description string    `json:"description,omitempty"`
+	Successes   int       `json:"successes"`
+	Failures    int       `json:"failures"`
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+	// Sources lists the instrumentation scope names (e.g.
+	// "compliance-dsar") that emitted evidence for this control — the
+	// closest thing a span carries to "what code path produced this".
+	Sources []string `json:"sources"`
+}
+
+// GapControl is a control declared via compliance.Register that produced
+// no evidence at all within the analysis window.
+type GapControl struct {
+	Framework   string `json:"framework"`
+	Control     string `json:"control"`
+	Description string `json:"description"`
+}
+
+// EvidencePacket is one sampled piece of evidence for a control: enough
+// for an auditor to read the request that produced it, the trace it
+// belongs to, and — if a Signer was attached when the span was recorded —
+// the signed attestation covering it.
+type EvidencePacket struct {
+	Framework  string                 `json:"framework"`
+	Control    string                 `json:"control"`
+	Result     string                 `json:"result"`
+	RecordedAt time.Time              `json:"recordedAt"`
+	DurationMs int64                  `json:"durationMs"`
+	TraceID    string                 `json:"traceId"`
+	SpanID     string                 `json:"spanId"`
+	Source     string                 `json:"source"`
+	Inputs     map[string]interface{} `json:"inputs,omitempty"`
+	Outputs    map[string]interface{} `json:"outputs,omitempty"`
+	Attested   bool                   `json:"attested"`
+	Envelope   *attest.Envelope       `json:"envelope,omitempty"`
+}
+
+// Report is the full output of an Analyzer run.
+type Report struct {
+	GeneratedAt time.Time                   `json:"generatedAt"`
+	Window      Window                      `json:"window"`
+	Coverage    []ControlCoverage           `json:"coverage"`
+	Gaps        []GapControl                `json:"gaps"`
+	Samples     map[string][]EvidencePacket `json:"samples"`
+}
+
+// EnvelopeLookup resolves the signed DSSE envelope recorded for a span, if
+// any. An Analyzer without one still reports which evidence was attested
+// (GDPRSpan/SOC2Span.End records that on the span itself whenever an
+// attester is configured; a span without the attribute counts as not
+// attested); it just
+// can't attach the envelope to the sampled packet.
+type EnvelopeLookup interface {
+	Envelope(ctx context.Context, traceID, spanID string) (attest.Envelope, bool, error)
+}
+
+// Analyzer builds a Report from raw OTLP evidence spans.
+type Analyzer struct {
+	// SampleSize is how many evidence packets Analyze samples per
+	// control. Zero disables sampling.
+	SampleSize int
+	// Envelopes, if set, is consulted for every sampled packet.
+	Envelopes EnvelopeLookup
+}
+
+// NewAnalyzer returns an Analyzer sampling sampleSize packets per control,
+// attaching attestations looked up via envelopes if non-nil.
+func NewAnalyzer(sampleSize int, envelopes EnvelopeLookup) *Analyzer {
+	return &Analyzer{SampleSize: sampleSize, Envelopes: envelopes}
+}
+
+// Analyze walks batches (as read from a WAL segment or cold archive),
+// keeps the evidence events falling within window, and builds the
+// control-coverage matrix, gap report, and evidence samples from them.
+func (a *Analyzer) Analyze(ctx context.Context, batches []*tracepb.ResourceSpans, window Window) (*Report, error) {
+	var events []evidenceEvent
+	for _, ev := range extractEvidence(batches) {
+		if window.contains(ev.RecordedAt) {
+			events = append(events, ev)
+		}
+	}
+
+	coverage := buildCoverage(events)
+	samples, err := a.buildSamples(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		GeneratedAt: time.Now().UTC(),
+		Window:      window,
+		Coverage:    coverage,
+		Gaps:        buildGaps(coverage),
+		Samples:     samples,
+	}, nil
+}
+
+// descriptionFor returns the registered description for control, or "" if
+// it was never registered via compliance.Register.
+func descriptionFor(control string) string {
+	for _, c := range compliance.Controls() {
+		if c.Control == control {
+			return c.Description
+		}
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
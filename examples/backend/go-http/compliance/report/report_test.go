@@ -0,0 +1,184 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/attest"
+)
+
+func strVal(v string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+}
+
+func intVal(v int64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+}
+
+func boolVal(v bool) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+}
+
+// evidenceSpan builds a ResourceSpans holding a single span shaped like
+// what BeginGDPRSpan/BeginSOC2Span emit, for exercising Analyze without a
+// real OTel SDK or WAL.
+func evidenceSpan(scope, framework, control, result string, recordedAt time.Time, spanID byte, attested bool) *tracepb.ResourceSpans {
+	return &tracepb.ResourceSpans{
+		ScopeSpans: []*tracepb.ScopeSpans{{
+			Scope: &commonpb.InstrumentationScope{Name: scope},
+			Spans: []*tracepb.Span{{
+				TraceId:           []byte{spanID, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				SpanId:            []byte{spanID, 0, 0, 0, 0, 0, 0, 0},
+				Name:              framework + "." + control,
+				StartTimeUnixNano: uint64(recordedAt.UnixNano()),
+				Attributes: []*commonpb.KeyValue{
+					{Key: "compliance.framework", Value: strVal(framework)},
+					{Key: "compliance.control", Value: strVal(control)},
+					{Key: "compliance.type", Value: strVal("evidence")},
+					{Key: "compliance.result", Value: strVal(result)},
+					{Key: "compliance.duration_ms", Value: intVal(12)},
+					{Key: "compliance.attestation.signed", Value: boolVal(attested)},
+					{Key: "input.subjectId", Value: strVal("sub-1")},
+					{Key: "output.recordsFound", Value: intVal(1)},
+				},
+			}},
+		}},
+	}
+}
+
+func TestAnalyzeBuildsCoverageMatrix(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	batches := []*tracepb.ResourceSpans{
+		evidenceSpan("compliance-gdpr", "gdpr", "Art.15", "success", now, 1, false),
+		evidenceSpan("compliance-gdpr", "gdpr", "Art.15", "failure", now.Add(time.Minute), 2, false),
+		evidenceSpan("compliance-soc2", "soc2", "CC6.1", "success", now, 3, false),
+	}
+
+	a := NewAnalyzer(0, nil)
+	rep, err := a.Analyze(context.Background(), batches, Window{})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	var art15 *ControlCoverage
+	for i := range rep.Coverage {
+		if rep.Coverage[i].Control == "Art.15" {
+			art15 = &rep.Coverage[i]
+		}
+	}
+	if art15 == nil {
+		t.Fatal("expected Art.15 in coverage matrix")
+	}
+	if art15.Successes != 1 || art15.Failures != 1 {
+		t.Fatalf("Art.15 coverage: got %+v, want 1 success and 1 failure", art15)
+	}
+	if !art15.LastSeen.After(art15.FirstSeen) {
+		t.Fatalf("Art.15 coverage: LastSeen %s should be after FirstSeen %s", art15.LastSeen, art15.FirstSeen)
+	}
+}
+
+func TestAnalyzeFlagsGapsForRegisteredControls(t *testing.T) {
+	batches := []*tracepb.ResourceSpans{
+		evidenceSpan("compliance-gdpr", "gdpr", "Art.15", "success", time.Now(), 1, false),
+	}
+
+	a := NewAnalyzer(0, nil)
+	rep, err := a.Analyze(context.Background(), batches, Window{})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	var gotGap bool
+	for _, g := range rep.Gaps {
+		if g.Control == "Art.32" {
+			gotGap = true
+		}
+		if g.Control == "Art.15" {
+			t.Fatal("Art.15 produced evidence, it should not be reported as a gap")
+		}
+	}
+	if !gotGap {
+		t.Fatal("expected Art.32 (registered, no evidence in this batch) to be reported as a gap")
+	}
+}
+
+func TestAnalyzeWindowExcludesOutOfRangeEvidence(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	batches := []*tracepb.ResourceSpans{
+		evidenceSpan("compliance-gdpr", "gdpr", "Art.17", "success", now.Add(-48*time.Hour), 1, false),
+		evidenceSpan("compliance-gdpr", "gdpr", "Art.17", "success", now, 2, false),
+	}
+
+	a := NewAnalyzer(0, nil)
+	rep, err := a.Analyze(context.Background(), batches, Window{Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	for _, c := range rep.Coverage {
+		if c.Control == "Art.17" && c.Successes != 1 {
+			t.Fatalf("Art.17 coverage: got %d successes, want 1 (the in-window event only)", c.Successes)
+		}
+	}
+}
+
+type fakeEnvelopeLookup struct{}
+
+func (fakeEnvelopeLookup) Envelope(ctx context.Context, traceID, spanID string) (attest.Envelope, bool, error) {
+	return attest.Envelope{PayloadType: attest.PayloadType, Payload: "cGF5bG9hZA=="}, true, nil
+}
+
+func TestAnalyzeSamplesAttachEnvelopes(t *testing.T) {
+	batches := []*tracepb.ResourceSpans{
+		evidenceSpan("compliance-gdpr", "gdpr", "Art.15", "success", time.Now(), 1, true),
+	}
+
+	a := NewAnalyzer(5, fakeEnvelopeLookup{})
+	rep, err := a.Analyze(context.Background(), batches, Window{})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	packets := rep.Samples["Art.15"]
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 sampled packet, got %d", len(packets))
+	}
+	if packets[0].Envelope == nil {
+		t.Fatal("expected the attested packet's envelope to be attached")
+	}
+	if packets[0].Inputs["subjectId"] != "sub-1" {
+		t.Fatalf("expected sampled packet to carry the span's input attributes, got %+v", packets[0].Inputs)
+	}
+}
+
+func TestReportJSONAndMarkdownAndHTML(t *testing.T) {
+	a := NewAnalyzer(1, nil)
+	rep, err := a.Analyze(context.Background(), []*tracepb.ResourceSpans{
+		evidenceSpan("compliance-gdpr", "gdpr", "Art.15", "success", time.Now(), 1, false),
+	}, Window{})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	data, err := rep.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if len(data) == 0 {
+		t.Fatal("expected non-empty JSON report")
+	}
+
+	md := rep.Markdown()
+	if md == "" {
+		t.Fatal("expected non-empty Markdown report")
+	}
+
+	page := rep.HTML()
+	if page == "" {
+		t.Fatal("expected non-empty HTML report")
+	}
+}
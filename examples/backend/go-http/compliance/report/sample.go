@@ -0,0 +1,60 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// buildSamples groups events by control and returns up to a.SampleSize
+// packets per control, chosen uniformly at random so a repeated run over
+// the same window doesn't always surface the same handful of requests.
+func (a *Analyzer) buildSamples(ctx context.Context, events []evidenceEvent) (map[string][]EvidencePacket, error) {
+	if a.SampleSize <= 0 {
+		return nil, nil
+	}
+
+	byControl := make(map[string][]evidenceEvent)
+	for _, ev := range events {
+		byControl[ev.Control] = append(byControl[ev.Control], ev)
+	}
+
+	out := make(map[string][]EvidencePacket, len(byControl))
+	for control, evs := range byControl {
+		rand.Shuffle(len(evs), func(i, j int) { evs[i], evs[j] = evs[j], evs[i] })
+		if len(evs) > a.SampleSize {
+			evs = evs[:a.SampleSize]
+		}
+
+		packets := make([]EvidencePacket, 0, len(evs))
+		for _, ev := range evs {
+			packet := EvidencePacket{
+				Framework:  ev.Framework,
+				Control:    ev.Control,
+				Result:     ev.Result,
+				RecordedAt: ev.RecordedAt,
+				DurationMs: ev.DurationMs,
+				TraceID:    ev.TraceID,
+				SpanID:     ev.SpanID,
+				Source:     ev.Source,
+				Inputs:     ev.Inputs,
+				Outputs:    ev.Outputs,
+				Attested:   ev.Attested,
+			}
+			if ev.Attested && a.Envelopes != nil {
+				env, ok, err := a.Envelopes.Envelope(ctx, ev.TraceID, ev.SpanID)
+				if err != nil {
+					return nil, fmt.Errorf("report: looking up envelope for span %s: %w", ev.SpanID, err)
+				}
+				if ok {
+					packet.Envelope = &env
+				}
+			}
+			packets = append(packets, packet)
+		}
+		sort.Slice(packets, func(i, j int) bool { return packets[i].RecordedAt.Before(packets[j].RecordedAt) })
+		out[control] = packets
+	}
+	return out, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/attest"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/policy"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -20,16 +22,35 @@ const (
 
 var soc2Tracer = otel.Tracer("compliance-soc2")
 
+func init() {
+	Register(CC6_1, "Logical Access Controls")
+	Register(CC6_6, "Logical and Physical Access Controls")
+	Register(CC6_8, "Change Management")
+	Register(CC7_2, "System Monitoring")
+}
+
 // SOC2Span represents a SOC 2 compliance evidence span
 type SOC2Span struct {
-	span  trace.Span
-	ctx   context.Context
-	start time.Time
+	span         trace.Span
+	ctx          context.Context
+	start        time.Time
+	control      string
+	engine       policy.Engine
+	inputs       map[string]interface{}
+	outputs      map[string]interface{}
+	decided      bool
+	lastDecision policy.Decision
+	attester     *attest.SpanAttester
 }
 
 // BeginSOC2Span starts a new SOC 2 evidence span
-func BeginSOC2Span(ctx context.Context, control string) *SOC2Span {
-	spanCtx, span := soc2Tracer.Start(ctx, "soc2."+control)
+func BeginSOC2Span(ctx context.Context, control string, opts ...Option) *SOC2Span {
+	cfg := &spanConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	spanCtx, span := soc2Tracer.Start(ctx, "soc2."+control, trace.WithLinks(cfg.links...))
 
 	span.SetAttributes(
 		attribute.String("compliance.framework", "soc2"),
@@ -38,42 +59,130 @@ func BeginSOC2Span(ctx context.Context, control string) *SOC2Span {
 	)
 
 	return &SOC2Span{
-		span:  span,
-		ctx:   spanCtx,
-		start: time.Now(),
+		span:     span,
+		ctx:      spanCtx,
+		start:    time.Now(),
+		control:  control,
+		engine:   cfg.engine,
+		inputs:   make(map[string]interface{}),
+		outputs:  make(map[string]interface{}),
+		attester: cfg.attester,
 	}
 }
 
 // SetInput adds an input attribute to the evidence span
 func (s *SOC2Span) SetInput(key string, value interface{}) {
+	s.inputs[key] = value
 	s.setAttribute("input."+key, value)
 }
 
 // SetOutput adds an output attribute to the evidence span
 func (s *SOC2Span) SetOutput(key string, value interface{}) {
+	s.outputs[key] = value
 	s.setAttribute("output."+key, value)
 }
 
 // End completes the evidence span successfully
 func (s *SOC2Span) End() {
+	s.Decide()
 	s.span.SetAttributes(
 		attribute.String("compliance.result", "success"),
 		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
 	)
+	s.attest("success")
 	s.span.End()
 }
 
 // EndWithError completes the evidence span with an error
 func (s *SOC2Span) EndWithError(err error) {
+	s.Decide()
 	s.span.SetAttributes(
 		attribute.String("compliance.result", "failure"),
 		attribute.String("compliance.error", err.Error()),
 		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
 	)
 	s.span.RecordError(err)
+	s.attest("failure")
 	s.span.End()
 }
 
+// SetIdentity records the caller's workload identity on the evidence span:
+// its SPIFFE ID, and whether it was established via a short-lived mTLS
+// certificate or a bearer token. Call it before End/EndWithError once the
+// caller's credentials have been inspected.
+func (s *SOC2Span) SetIdentity(spiffeID, authMethod string) {
+	s.span.SetAttributes(
+		attribute.String("compliance.identity.spiffe_id", spiffeID),
+		attribute.String("compliance.identity.auth_method", authMethod),
+	)
+}
+
+// attest signs the collected evidence via the configured SpanAttester, if
+// any. See GDPRSpan.attest for the failure-handling contract.
+func (s *SOC2Span) attest(result string) {
+	if s.attester == nil {
+		return
+	}
+
+	sc := s.span.SpanContext()
+	_, err := s.attester.Attest(s.ctx, attest.Evidence{
+		Framework:  "soc2",
+		Control:    s.control,
+		Inputs:     s.inputs,
+		Outputs:    s.outputs,
+		Result:     result,
+		Duration:   time.Since(s.start),
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		Decision:   string(s.lastDecision.Result),
+		RecordedAt: s.start,
+	})
+
+	s.span.SetAttributes(attribute.Bool("compliance.attestation.signed", err == nil))
+	if err != nil {
+		s.span.SetAttributes(attribute.String("compliance.attestation.error", err.Error()))
+	}
+}
+
+// Decide evaluates the configured policy engine against the evidence
+// collected so far and records the outcome as a compliance.decision
+// attribute, returning it so callers can act on it before the span ends.
+// See GDPRSpan.Decide for the idempotency contract.
+func (s *SOC2Span) Decide() policy.Decision {
+	if s.decided {
+		return s.lastDecision
+	}
+	s.decided = true
+
+	if s.engine == nil {
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "no-engine"}
+		return s.lastDecision
+	}
+
+	decision, err := s.engine.Evaluate(s.ctx, policy.Input{
+		Framework: "soc2",
+		Control:   s.control,
+		Inputs:    s.inputs,
+		Outputs:   s.outputs,
+	})
+	if err != nil {
+		s.span.SetAttributes(attribute.String("compliance.decision.error", err.Error()))
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "engine-error"}
+		return s.lastDecision
+	}
+
+	s.span.SetAttributes(
+		attribute.String("compliance.decision", string(decision.Result)),
+		attribute.String("compliance.decision.rule_id", decision.RuleID),
+	)
+	if decision.Reason != "" {
+		s.span.SetAttributes(attribute.String("compliance.decision.reason", decision.Reason))
+	}
+
+	s.lastDecision = decision
+	return decision
+}
+
 func (s *SOC2Span) setAttribute(key string, value interface{}) {
 	switch v := value.(type) {
 	case string:
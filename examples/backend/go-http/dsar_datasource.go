@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/dsar"
+)
+
+// usersDataSource exposes inMemoryDB as a dsar.DataSource, so the DSAR
+// Coordinator can fan access/rectification/erasure/portability requests
+// out to it the same way it would to any other source.
+type usersDataSource struct{}
+
+func (usersDataSource) Name() string { return "users-db" }
+
+func (usersDataSource) Locate(ctx context.Context, subjectID string) ([]dsar.Record, error) {
+	user, ok := inMemoryDB[subjectID]
+	if !ok {
+		return nil, nil
+	}
+	return []dsar.Record{{
+		Source: "users-db",
+		Type:   "user_profile",
+		Data: map[string]interface{}{
+			"id":    user.ID,
+			"email": user.Email,
+			"name":  user.Name,
+		},
+	}}, nil
+}
+
+func (s usersDataSource) Export(ctx context.Context, subjectID string, w io.Writer) error {
+	records, err := s.Locate(ctx, subjectID)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(records)
+}
+
+func (usersDataSource) Erase(ctx context.Context, subjectID string) (dsar.ErasureReport, error) {
+	erased := 0
+	if _, ok := inMemoryDB[subjectID]; ok {
+		delete(inMemoryDB, subjectID)
+		erased = 1
+	}
+	return dsar.ErasureReport{
+		Source:        "users-db",
+		RecordsErased: erased,
+		TablesCleared: []string{"users"},
+	}, nil
+}
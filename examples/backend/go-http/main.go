@@ -1,19 +1,40 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"sync/atomic"
+	"time"
 
 	"github.com/fluohq/compliance-as-code/examples/go-http/compliance"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/attest"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/dsar"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/export"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/identity"
+	"github.com/fluohq/compliance-as-code/examples/go-http/compliance/policy"
+	"go.opentelemetry.io/otel"
 )
 
+// defaultPolicyBundleDir is the fallback bundle directory when
+// POLICY_BUNDLE_DIR is unset.
+const defaultPolicyBundleDir = "policies"
+
+// defaultWALDir is the fallback durable evidence WAL directory when
+// COMPLIANCE_WAL_DIR is unset.
+const defaultWALDir = "./compliance-evidence"
+
 var (
-	version    = "1.0.0"
-	requestID  int64
-	inMemoryDB = make(map[string]*User)
+	version         = "1.0.0"
+	requestID       int64
+	inMemoryDB      = make(map[string]*User)
+	policyEngine    = policy.NewBundleEngine()
+	spanAttester    *attest.SpanAttester // nil unless ATTEST_ENABLED=true
+	dsarCoordinator *dsar.Coordinator
 )
 
 type User struct {
@@ -27,68 +48,44 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
-// Get user data - implements GDPR Right of Access (Art.15)
+// Get user data - implements GDPR Right of Access (Art.15) via the DSAR
+// Coordinator, which emits the evidence span on usersDataSource's behalf.
 func getUser(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	// Add compliance evidence to context
-	span := compliance.BeginGDPRSpan(ctx, compliance.Art_15)
-	defer span.End()
-
 	userID := r.URL.Query().Get("id")
 	if userID == "" {
-		span.EndWithError(fmt.Errorf("missing user id"))
 		writeError(w, http.StatusBadRequest, "missing user id parameter")
 		return
 	}
 
-	span.SetInput("userId", userID)
-	span.SetInput("http.method", r.Method)
-	span.SetInput("http.path", r.URL.Path)
-
-	// Fetch user from in-memory DB
-	user, exists := inMemoryDB[userID]
-	if !exists {
-		span.EndWithError(fmt.Errorf("user not found"))
+	report, err := dsarCoordinator.Handle(r.Context(), dsar.Access, userID, r)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if len(report.Records) == 0 {
 		writeError(w, http.StatusNotFound, "user not found")
 		return
 	}
 
-	span.SetOutput("email", user.Email)
-	span.SetOutput("recordsReturned", 1)
-
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(user)
+	json.NewEncoder(w).Encode(report.Records[0].Data)
 }
 
-// Delete user data - implements GDPR Right to Erasure (Art.17)
+// Delete user data - implements GDPR Right to Erasure (Art.17) via the
+// DSAR Coordinator, which emits the evidence span on usersDataSource's
+// behalf.
 func deleteUser(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	// Add compliance evidence
-	span := compliance.BeginGDPRSpan(ctx, compliance.Art_17)
-	defer span.End()
-
 	userID := r.URL.Query().Get("id")
 	if userID == "" {
-		span.EndWithError(fmt.Errorf("missing user id"))
 		writeError(w, http.StatusBadRequest, "missing user id parameter")
 		return
 	}
 
-	span.SetInput("userId", userID)
-	span.SetInput("http.method", r.Method)
-
-	// Delete user
-	deleted := 0
-	if _, exists := inMemoryDB[userID]; exists {
-		delete(inMemoryDB, userID)
-		deleted = 1
+	if _, err := dsarCoordinator.Handle(r.Context(), dsar.Erasure, userID, r); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
 	}
 
-	span.SetOutput("deletedRecords", deleted)
-	span.SetOutput("tablesCleared", 1)
-
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -97,10 +94,10 @@ func createUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Multi-framework evidence
-	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_51f)
+	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_51f, compliance.WithPolicyEngine(policyEngine), compliance.WithAttestation(spanAttester))
 	defer gdprSpan.End()
 
-	soc2Span := compliance.BeginSOC2Span(ctx, compliance.CC6_1)
+	soc2Span := compliance.BeginSOC2Span(ctx, compliance.CC6_1, compliance.WithPolicyEngine(policyEngine), compliance.WithAttestation(spanAttester))
 	defer soc2Span.End()
 
 	var user User
@@ -116,10 +113,20 @@ func createUser(w http.ResponseWriter, r *http.Request) {
 	user.ID = fmt.Sprintf("user_%d", reqID)
 
 	gdprSpan.SetInput("email", user.Email)
+	gdprSpan.SetInput("hasEmail", user.Email != "")
 	gdprSpan.SetInput("http.method", r.Method)
 
 	soc2Span.SetInput("userId", user.ID)
 	soc2Span.SetInput("action", "create_user")
+	spiffeID, authMethod := callerIdentity(r)
+	soc2Span.SetIdentity(spiffeID, authMethod)
+
+	if decision := gdprSpan.Decide(); decision.Result == policy.Deny {
+		gdprSpan.SetOutput("recordsCreated", 0)
+		soc2Span.SetOutput("authorized", false)
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("request denied by policy %s: %s", decision.RuleID, decision.Reason))
+		return
+	}
 
 	// Save to in-memory DB (password would be hashed in real implementation)
 	inMemoryDB[user.ID] = &user
@@ -169,6 +176,70 @@ func health(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// callerIdentity reports how the caller authenticated: its SPIFFE ID and
+// "mtls" if it presented a client certificate over mutual TLS, or "bearer"
+// if it only presented an Authorization header, or "none" if neither.
+func callerIdentity(r *http.Request) (spiffeID, authMethod string) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		id, err := identity.SPIFFEIDFromCertificate(r.TLS.PeerCertificates[0])
+		if err == nil {
+			return id, "mtls"
+		}
+	}
+	if r.Header.Get("Authorization") != "" {
+		return "", "bearer"
+	}
+	return "", "none"
+}
+
+// mtlsConfig builds the server's mTLS config from environment-driven
+// workload identity sources, preferring a SPIFFE-style file source (the
+// sidecar/SPIRE pattern) and falling back to a Step/CFSSL CA when SPIFFE
+// isn't available. Returns nil, nil if neither is configured.
+func mtlsConfig(ctx context.Context) (*tls.Config, error) {
+	trustDomain := os.Getenv("SPIFFE_TRUST_DOMAIN")
+	var authorizer identity.Authorizer
+	if trustDomain != "" {
+		authorizer = identity.AuthorizeMemberOf(trustDomain)
+	}
+
+	if svidPath := os.Getenv("SPIFFE_SVID_PATH"); svidPath != "" {
+		source, err := identity.NewFileSource(svidPath, os.Getenv("SPIFFE_KEY_PATH"), os.Getenv("SPIFFE_BUNDLE_PATH"))
+		if err != nil {
+			return nil, fmt.Errorf("loading SPIFFE SVID: %w", err)
+		}
+		go func() {
+			if err := source.Watch(ctx, 30*time.Second); err != nil && ctx.Err() == nil {
+				log.Printf("SPIFFE SVID watcher stopped: %v", err)
+			}
+		}()
+		return identity.ServerTLSConfig(source, authorizer)
+	}
+
+	if caURL := os.Getenv("CA_URL"); caURL != "" {
+		rotation := 1 * time.Hour
+		if v := os.Getenv("CERT_ROTATION_INTERVAL"); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("parsing CERT_ROTATION_INTERVAL: %w", err)
+			}
+			rotation = parsed
+		}
+		source, err := identity.NewStepSource(ctx, caURL, os.Getenv("PROVISIONER_TOKEN"), os.Getenv("SPIFFE_ID"), rotation)
+		if err != nil {
+			return nil, fmt.Errorf("issuing certificate from %s: %w", caURL, err)
+		}
+		go func() {
+			if err := source.Watch(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Step certificate renewal stopped: %v", err)
+			}
+		}()
+		return identity.ServerTLSConfig(source, authorizer)
+	}
+
+	return nil, nil
+}
+
 func writeError(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -179,6 +250,24 @@ func writeError(w http.ResponseWriter, status int, message string) {
 }
 
 func main() {
+	if endpoint := os.Getenv("COMPLIANCE_OTLP_ENDPOINT"); endpoint != "" {
+		walDir := os.Getenv("COMPLIANCE_WAL_DIR")
+		if walDir == "" {
+			walDir = defaultWALDir
+		}
+		var opts []export.Option
+		if os.Getenv("COMPLIANCE_OTLP_INSECURE") == "true" {
+			opts = append(opts, export.WithInsecure())
+		}
+		tp, err := export.NewDurableTracerProvider(walDir, endpoint, opts...)
+		if err != nil {
+			log.Fatalf("opening evidence WAL at %s: %v", walDir, err)
+		}
+		otel.SetTracerProvider(tp)
+		defer tp.Shutdown(context.Background())
+		log.Printf("Durable evidence export enabled: WAL at %s, shipping to %s", walDir, endpoint)
+	}
+
 	// Seed some data
 	inMemoryDB["123"] = &User{
 		ID:    "123",
@@ -191,7 +280,51 @@ func main() {
 		Name:  "Bob",
 	}
 
+	bundleDir := os.Getenv("POLICY_BUNDLE_DIR")
+	if bundleDir == "" {
+		bundleDir = defaultPolicyBundleDir
+	}
+	loader := policy.NewLoader(bundleDir, policyEngine)
+	if err := loader.Load(); err != nil {
+		log.Printf("warning: could not load policy bundles from %s: %v", bundleDir, err)
+	} else {
+		go func() {
+			if err := loader.Watch(context.Background(), 5*time.Second); err != nil {
+				log.Printf("policy bundle watcher stopped: %v", err)
+			}
+		}()
+	}
+
+	if os.Getenv("ATTEST_ENABLED") == "true" {
+		_, priv, err := attest.GenerateEd25519Key()
+		if err != nil {
+			log.Fatalf("generating attestation signing key: %v", err)
+		}
+		signer := attest.NewLocalSigner("go-http-demo-key", priv)
+		spanAttester = attest.NewSpanAttester(signer, nil, nil)
+		log.Println("Attestation enabled: evidence spans will be signed with an ephemeral local key")
+		log.Println("(set a persistent key or a KMS/Fulcio signer for production use)")
+	}
+
+	registry := dsar.NewRegistry()
+	registry.Register(usersDataSource{})
+
+	var dsarLedger dsar.Ledger
+	if path := os.Getenv("DSAR_LEDGER_PATH"); path != "" {
+		ledger, err := dsar.NewSQLiteLedger(path)
+		if err != nil {
+			log.Fatalf("opening DSAR ledger at %s: %v", path, err)
+		}
+		dsarLedger = ledger
+		log.Printf("DSAR request ledger: SQLite at %s", path)
+	}
+	dsarCoordinator = dsar.NewCoordinator(registry, dsarLedger,
+		dsar.WithPolicyEngine(policyEngine),
+		dsar.WithAttestation(spanAttester),
+	)
+
 	http.HandleFunc("/health", health)
+	http.Handle("/dsar/", dsarCoordinator)
 	http.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -219,8 +352,26 @@ func main() {
 	log.Println("  GET    /user               - List users (GDPR Art.15)")
 	log.Println("  POST   /user               - Create user (GDPR Art.5(1)(f), SOC2 CC6.1)")
 	log.Println("  DELETE /user?id=123        - Delete user (GDPR Art.17)")
+	log.Println("  *      /dsar/{access,rectification,erasure,portability}?subject=123 - DSAR workflows")
 	log.Println("")
 	log.Println("Configure OTEL_EXPORTER_OTLP_ENDPOINT to emit evidence spans")
+	log.Println("Configure DSAR_LEDGER_PATH to persist DSAR request state across restarts")
+
+	if os.Getenv("MTLS_ENABLED") == "true" {
+		tlsCfg, err := mtlsConfig(context.Background())
+		if err != nil {
+			log.Fatalf("configuring mTLS: %v", err)
+		}
+		if tlsCfg == nil {
+			log.Fatal("MTLS_ENABLED=true requires SPIFFE_SVID_PATH or CA_URL to be set")
+		}
+		log.Println("Requiring mTLS client certificates (SPIFFE workload identity); serving on :8443 instead of :8080")
+		server := &http.Server{Addr: ":8443", TLSConfig: tlsCfg}
+		if err := server.ListenAndServeTLS("", ""); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	if err := http.ListenAndServe(":8080", nil); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const ackFileName = "acked.json"
+
+// ackTracker records, per segment, how many of its records have been
+// successfully uploaded. It is the WAL's durability boundary: the pump
+// only ever re-sends records at or after a segment's acked count, so a
+// crash between "upload succeeded" and "ack persisted" can cause at most
+// one duplicate re-send of the in-flight batch, never a full replay of
+// already-acked records.
+type ackTracker struct {
+	path string
+
+	mu    sync.Mutex
+	Acked map[string]int `json:"acked"` // segment name -> records acknowledged
+}
+
+func openAckTracker(dir string) (*ackTracker, error) {
+	t := &ackTracker{path: filepath.Join(dir, ackFileName), Acked: make(map[string]int)}
+
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return t, nil
+		}
+		return nil, fmt.Errorf("export: reading ack file %s: %w", t.path, err)
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("export: parsing ack file %s: %w", t.path, err)
+	}
+	return t, nil
+}
+
+// AckedCount returns how many of segment's records have already been uploaded.
+func (t *ackTracker) AckedCount(segment string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.Acked[segment]
+}
+
+// Ack records that segment's first n records have been uploaded, and
+// persists the tracker to disk before returning.
+func (t *ackTracker) Ack(segment string, n int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Acked[segment] = n
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("export: encoding ack file: %w", err)
+	}
+
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("export: writing ack file %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, t.path); err != nil {
+		return fmt.Errorf("export: committing ack file %s: %w", t.path, err)
+	}
+	return nil
+}
+
+// Forget removes a segment's entry, used once it has been compacted away.
+func (t *ackTracker) Forget(segment string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.Acked, segment)
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("export: encoding ack file: %w", err)
+	}
+
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("export: writing ack file %s: %w", tmp, err)
+	}
+	return os.Rename(tmp, t.path)
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// coldSubdir is where Compact writes its archives by default, relative
+// to the WAL's directory.
+const coldSubdir = "cold"
+
+// Compact merges every fully-acknowledged WAL segment under dir, other
+// than openSegment (the segment currently open for writes — pass the
+// live WAL's currentSegment() here, never a value recomputed from a
+// throwaway WAL handle, or Compact will delete a segment still being
+// appended to), that is older than olderThan into a single
+// gzip-compressed archive of length-prefixed protobuf records (the same
+// framing ReadSegment expects, minus the WAL's fsync-recovery magic,
+// since a cold archive is never partially written). The archive is named
+// after the oldest segment it contains and is written under coldDir, or
+// dir/cold if coldDir is empty. Source segments are removed, and
+// forgotten from acks, once the archive has been written. Compact is a
+// no-op, returning "", 0, nil, if no segment qualifies.
+func Compact(dir string, olderThan time.Duration, coldDir string, acks *ackTracker, openSegment string) (archive string, merged int, err error) {
+	wal := &WAL{Dir: dir}
+	segments, err := wal.Segments()
+	if err != nil {
+		return "", 0, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+
+	var toMerge []string
+	records := make(map[string][]*tracepb.ResourceSpans, len(segments))
+	for _, name := range segments {
+		if name == openSegment {
+			continue
+		}
+		created, err := segmentCreatedAt(name)
+		if err != nil || created.After(cutoff) {
+			continue
+		}
+
+		segRecords, err := ReadSegment(wal.Path(name))
+		if err != nil {
+			return "", 0, fmt.Errorf("export: reading segment %s for compaction: %w", name, err)
+		}
+		if acks.AckedCount(name) < len(segRecords) {
+			continue // still has evidence the pump hasn't shipped yet
+		}
+		records[name] = segRecords
+		toMerge = append(toMerge, name)
+	}
+
+	if len(toMerge) == 0 {
+		return "", 0, nil
+	}
+
+	if coldDir == "" {
+		coldDir = filepath.Join(dir, coldSubdir)
+	}
+	if err := os.MkdirAll(coldDir, 0o755); err != nil {
+		return "", 0, fmt.Errorf("export: creating cold archive dir %s: %w", coldDir, err)
+	}
+
+	archive = filepath.Join(coldDir, toMerge[0]+".gz")
+	if err := writeColdArchive(archive, toMerge, records); err != nil {
+		return "", 0, err
+	}
+
+	for _, name := range toMerge {
+		if err := wal.RemoveSegment(name); err != nil {
+			return archive, merged, err
+		}
+		if err := acks.Forget(name); err != nil {
+			return archive, merged, err
+		}
+		merged++
+	}
+
+	return archive, merged, nil
+}
+
+func writeColdArchive(path string, segments []string, records map[string][]*tracepb.ResourceSpans) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("export: creating cold archive %s: %w", tmp, err)
+	}
+
+	if err := writeColdRecords(f, segments, records); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("export: fsyncing cold archive %s: %w", tmp, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("export: closing cold archive %s: %w", tmp, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func writeColdRecords(f *os.File, segments []string, records map[string][]*tracepb.ResourceSpans) error {
+	gz := gzip.NewWriter(f)
+	for _, name := range segments {
+		for _, rs := range records[name] {
+			data, err := proto.Marshal(rs)
+			if err != nil {
+				return fmt.Errorf("export: marshaling record from %s: %w", name, err)
+			}
+			if err := writeColdRecord(gz, data); err != nil {
+				return err
+			}
+		}
+	}
+	return gz.Close()
+}
+
+// writeColdRecord writes a 4-byte big-endian length prefix followed by
+// data; a cold archive has no fsync-recovery magic because, unlike a WAL
+// segment, it's only ever written once, in full, before being renamed
+// into place.
+func writeColdRecord(w io.Writer, data []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := w.Write(length[:]); err != nil {
+		return fmt.Errorf("export: writing cold record length: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("export: writing cold record body: %w", err)
+	}
+	return nil
+}
+
+// ReadColdArchive reads every record out of a gzip cold archive written
+// by Compact.
+func ReadColdArchive(path string) ([]*tracepb.ResourceSpans, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("export: opening cold archive %s: %w", path, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("export: opening gzip reader for %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	var records []*tracepb.ResourceSpans
+	r := bufio.NewReader(gz)
+	for {
+		var length [4]byte
+		if _, err := io.ReadFull(r, length[:]); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("export: reading cold record length in %s: %w", path, err)
+		}
+		data := make([]byte, binary.BigEndian.Uint32(length[:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("export: reading cold record body in %s: %w", path, err)
+		}
+		var spans tracepb.ResourceSpans
+		if err := proto.Unmarshal(data, &spans); err != nil {
+			return nil, fmt.Errorf("export: unmarshaling cold record in %s: %w", path, err)
+		}
+		records = append(records, &spans)
+	}
+	return records, nil
+}
@@ -0,0 +1,154 @@
+package export
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCompactMergesOnlyAckedSegmentsOlderThanCutoff(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := OpenWAL(dir)
+	if err != nil {
+		t.Fatalf("OpenWAL: %v", err)
+	}
+	wal.MaxSegmentBytes = 1 // force a new segment per record
+
+	if err := wal.Append(spanRecord(t, 1)); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	oldSegments, err := wal.Segments()
+	if err != nil {
+		t.Fatalf("Segments: %v", err)
+	}
+	if len(oldSegments) != 1 {
+		t.Fatalf("Segments: got %d, want 1", len(oldSegments))
+	}
+	oldSegment := oldSegments[0]
+
+	// Back-date the old segment's name so it looks like it was written
+	// well before the compaction cutoff.
+	staleName := segmentPrefix + "00000000000000000001" + segmentExt
+	if err := wal.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := os.Rename((&WAL{Dir: dir}).Path(oldSegment), (&WAL{Dir: dir}).Path(staleName)); err != nil {
+		t.Fatalf("renaming segment: %v", err)
+	}
+
+	wal, err = OpenWAL(dir)
+	if err != nil {
+		t.Fatalf("re-OpenWAL: %v", err)
+	}
+	wal.MaxSegmentBytes = 1
+	if err := wal.Append(spanRecord(t, 2)); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	segmentsAfter, err := wal.Segments()
+	if err != nil {
+		t.Fatalf("Segments: %v", err)
+	}
+	var freshSegment string
+	for _, name := range segmentsAfter {
+		if name != staleName {
+			freshSegment = name
+		}
+	}
+	if freshSegment == "" {
+		t.Fatal("expected a freshly written segment distinct from the back-dated one")
+	}
+
+	acks, err := openAckTracker(dir)
+	if err != nil {
+		t.Fatalf("openAckTracker: %v", err)
+	}
+	if err := acks.Ack(staleName, 1); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	// freshSegment is deliberately left unacked, and is also too new to
+	// be eligible regardless.
+
+	archive, merged, err := Compact(dir, time.Hour, "", acks, wal.currentSegment())
+	if err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if merged != 1 {
+		t.Fatalf("Compact: merged %d segments, want 1", merged)
+	}
+
+	records, err := ReadColdArchive(archive)
+	if err != nil {
+		t.Fatalf("ReadColdArchive: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("ReadColdArchive: got %d records, want 1", len(records))
+	}
+
+	remaining, err := wal.Segments()
+	if err != nil {
+		t.Fatalf("Segments (post-compact): %v", err)
+	}
+	if len(remaining) != 1 || remaining[0] != freshSegment {
+		t.Fatalf("Segments (post-compact): got %v, want only %q left behind", remaining, freshSegment)
+	}
+	if acks.AckedCount(staleName) != 0 {
+		t.Fatal("Compact: expected the merged segment's ack entry to be forgotten")
+	}
+}
+
+// TestCompactNeverRemovesTheOpenSegment guards against compacting the
+// segment a live WAL is still appending to: even if that segment is
+// fully acked and older than the cutoff, passing its name as openSegment
+// must keep Compact from touching it.
+func TestCompactNeverRemovesTheOpenSegment(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := OpenWAL(dir)
+	if err != nil {
+		t.Fatalf("OpenWAL: %v", err)
+	}
+	defer wal.Close()
+
+	if err := wal.Append(spanRecord(t, 1)); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	segments, err := wal.Segments()
+	if err != nil {
+		t.Fatalf("Segments: %v", err)
+	}
+	if len(segments) != 1 {
+		t.Fatalf("Segments: got %d, want 1", len(segments))
+	}
+	openSegment := segments[0]
+	if openSegment != wal.currentSegment() {
+		t.Fatalf("currentSegment: got %q, want %q", wal.currentSegment(), openSegment)
+	}
+
+	acks, err := openAckTracker(dir)
+	if err != nil {
+		t.Fatalf("openAckTracker: %v", err)
+	}
+	if err := acks.Ack(openSegment, 1); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	// Compact with a zero cutoff so every acked segment would otherwise
+	// qualify by age.
+	archive, merged, err := Compact(dir, 0, "", acks, wal.currentSegment())
+	if err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if merged != 0 || archive != "" {
+		t.Fatalf("Compact: merged %d segment(s) into %q, want the open segment left untouched", merged, archive)
+	}
+
+	remaining, err := wal.Segments()
+	if err != nil {
+		t.Fatalf("Segments (post-compact): %v", err)
+	}
+	if len(remaining) != 1 || remaining[0] != openSegment {
+		t.Fatalf("Segments (post-compact): got %v, want the open segment %q still present", remaining, openSegment)
+	}
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"encoding/hex"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Dedupe drops spans whose ID is already present in seen (which it
+// updates in place), removing now-empty scope/resource spans from the
+// result. It protects the pump and the replay tool against re-sending the
+// same span twice, which can happen if a WAL record is appended more than
+// once after a write that failed partway through before the caller
+// retried it.
+func Dedupe(batches []*tracepb.ResourceSpans, seen map[string]struct{}) []*tracepb.ResourceSpans {
+	var out []*tracepb.ResourceSpans
+
+	for _, rs := range batches {
+		var scopeSpans []*tracepb.ScopeSpans
+		for _, ss := range rs.GetScopeSpans() {
+			var spans []*tracepb.Span
+			for _, span := range ss.GetSpans() {
+				id := hex.EncodeToString(span.GetSpanId())
+				if _, dup := seen[id]; dup {
+					continue
+				}
+				seen[id] = struct{}{}
+				spans = append(spans, span)
+			}
+			if len(spans) == 0 {
+				continue
+			}
+			scopeSpans = append(scopeSpans, &tracepb.ScopeSpans{
+				Scope: ss.GetScope(),
+				Spans: spans,
+			})
+		}
+		if len(scopeSpans) == 0 {
+			continue
+		}
+		out = append(out, &tracepb.ResourceSpans{
+			Resource:   rs.GetResource(),
+			ScopeSpans: scopeSpans,
+		})
+	}
+
+	return out
+}
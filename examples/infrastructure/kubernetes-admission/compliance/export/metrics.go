@@ -0,0 +1,37 @@
+package export
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics are the Prometheus gauges the pump keeps up to date so an
+// operator can alert on an OTLP endpoint outage before the WAL grows
+// large enough to matter.
+type Metrics struct {
+	// QueueDepth is the number of WAL records not yet acknowledged by the
+	// OTLP endpoint.
+	QueueDepth prometheus.Gauge
+	// OldestUnackedAge is the age, in seconds, of the oldest WAL segment
+	// that still has unacknowledged records. It's 0 when the queue is
+	// empty.
+	OldestUnackedAge prometheus.Gauge
+}
+
+func newMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		QueueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "compliance_export",
+			Name:      "queue_depth",
+			Help:      "Number of WAL-buffered evidence spans not yet acknowledged by the OTLP endpoint.",
+		}),
+		OldestUnackedAge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "compliance_export",
+			Name:      "oldest_unacked_age_seconds",
+			Help:      "Age in seconds of the oldest WAL segment with unacknowledged records.",
+		}),
+	}
+
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	reg.MustRegister(m.QueueDepth, m.OldestUnackedAge)
+	return m
+}
@@ -0,0 +1,179 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluohq/compliance-as-code/examples/kubernetes-admission/compliance/policy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GDPR compliance controls
+const (
+	Art_15  = "Art.15"      // Right of Access
+	Art_17  = "Art.17"      // Right to Erasure
+	Art_51f = "Art.5(1)(f)" // Security of Processing
+	Art_32  = "Art.32"      // Security of Processing
+)
+
+var tracer = otel.Tracer("compliance-gdpr")
+
+// GDPRSpan represents a compliance evidence span
+type GDPRSpan struct {
+	span         trace.Span
+	ctx          context.Context
+	start        time.Time
+	control      string
+	engine       policy.Engine
+	inputs       map[string]interface{}
+	outputs      map[string]interface{}
+	decided      bool
+	lastDecision policy.Decision
+}
+
+// Option customizes a GDPRSpan or SOC2Span at creation time.
+type Option func(*spanConfig)
+
+type spanConfig struct {
+	engine policy.Engine
+}
+
+// WithPolicyEngine evaluates the span's inputs/outputs against engine when the
+// span ends, recording the resulting allow/deny/warn decision as evidence.
+func WithPolicyEngine(engine policy.Engine) Option {
+	return func(c *spanConfig) {
+		c.engine = engine
+	}
+}
+
+// BeginGDPRSpan starts a new GDPR evidence span
+func BeginGDPRSpan(ctx context.Context, control string, opts ...Option) *GDPRSpan {
+	cfg := &spanConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	spanCtx, span := tracer.Start(ctx, "gdpr."+control)
+
+	span.SetAttributes(
+		attribute.String("compliance.framework", "gdpr"),
+		attribute.String("compliance.control", control),
+		attribute.String("compliance.type", "evidence"),
+	)
+
+	return &GDPRSpan{
+		span:    span,
+		ctx:     spanCtx,
+		start:   time.Now(),
+		control: control,
+		engine:  cfg.engine,
+		inputs:  make(map[string]interface{}),
+		outputs: make(map[string]interface{}),
+	}
+}
+
+// SetInput adds an input attribute to the evidence span
+func (s *GDPRSpan) SetInput(key string, value interface{}) {
+	s.inputs[key] = value
+	s.setAttribute("input."+key, value)
+}
+
+// SetOutput adds an output attribute to the evidence span
+func (s *GDPRSpan) SetOutput(key string, value interface{}) {
+	s.outputs[key] = value
+	s.setAttribute("output."+key, value)
+}
+
+// SetIdentity records the caller's workload identity on the evidence span:
+// its SPIFFE ID, and whether it was established via a short-lived mTLS
+// certificate or a bearer token. Call it before End/EndWithError once the
+// caller's credentials have been inspected.
+func (s *GDPRSpan) SetIdentity(spiffeID, authMethod string) {
+	s.span.SetAttributes(
+		attribute.String("compliance.identity.spiffe_id", spiffeID),
+		attribute.String("compliance.identity.auth_method", authMethod),
+	)
+}
+
+// End completes the evidence span successfully
+func (s *GDPRSpan) End() {
+	s.Decide()
+	s.span.SetAttributes(
+		attribute.String("compliance.result", "success"),
+		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
+	)
+	s.span.End()
+}
+
+// EndWithError completes the evidence span with an error
+func (s *GDPRSpan) EndWithError(err error) {
+	s.Decide()
+	s.span.SetAttributes(
+		attribute.String("compliance.result", "failure"),
+		attribute.String("compliance.error", err.Error()),
+		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
+	)
+	s.span.RecordError(err)
+	s.span.End()
+}
+
+// Decide evaluates the configured policy engine against the evidence
+// collected so far and records the outcome as a compliance.decision
+// attribute, returning it so callers can act on it (e.g. an admission
+// controller denying a request) before the span ends. It is idempotent:
+// only the first call evaluates the engine, so callers that need the
+// decision to make a request-handling choice can call Decide explicitly and
+// End/EndWithError will not re-evaluate. Spans with no configured engine
+// always decide Allow.
+func (s *GDPRSpan) Decide() policy.Decision {
+	if s.decided {
+		return s.lastDecision
+	}
+	s.decided = true
+
+	if s.engine == nil {
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "no-engine"}
+		return s.lastDecision
+	}
+
+	decision, err := s.engine.Evaluate(s.ctx, policy.Input{
+		Framework: "gdpr",
+		Control:   s.control,
+		Inputs:    s.inputs,
+		Outputs:   s.outputs,
+	})
+	if err != nil {
+		s.span.SetAttributes(attribute.String("compliance.decision.error", err.Error()))
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "engine-error"}
+		return s.lastDecision
+	}
+
+	s.span.SetAttributes(
+		attribute.String("compliance.decision", string(decision.Result)),
+		attribute.String("compliance.decision.rule_id", decision.RuleID),
+	)
+	if decision.Reason != "" {
+		s.span.SetAttributes(attribute.String("compliance.decision.reason", decision.Reason))
+	}
+
+	s.lastDecision = decision
+	return decision
+}
+
+func (s *GDPRSpan) setAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case int64:
+		s.span.SetAttributes(attribute.Int64(key, v))
+	case bool:
+		s.span.SetAttributes(attribute.Bool(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprintf("%v", v)))
+	}
+}
@@ -0,0 +1,94 @@
+// Package identity provides SPIFFE-flavored workload identity for mutual
+// TLS: short-lived X.509 certificates (SVIDs) that rotate automatically,
+// an authorizer to match the peer's SPIFFE ID, and TLS config builders for
+// both server and client roles. A Step/CFSSL-issued certificate source is
+// provided as a fallback for environments without a SPIFFE Workload API.
+package identity
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// SVID is an X.509 SPIFFE Verifiable Identity Document: a short-lived
+// certificate chain plus the private key it was issued for, and the trust
+// bundle needed to verify peers in the same trust domain.
+type SVID struct {
+	// ID is the SPIFFE ID carried in the leaf certificate's URI SAN, e.g.
+	// "spiffe://example.org/ns/default/sa/compliance-admission".
+	ID string
+	// Certificates is the leaf certificate followed by any intermediates,
+	// suitable for tls.Certificate.Certificate.
+	Certificates [][]byte
+	// PrivateKey is the leaf certificate's private key.
+	PrivateKey crypto.Signer
+	// TrustBundle contains the CA certificates trusted to verify peer
+	// SVIDs in the same trust domain.
+	TrustBundle *x509.CertPool
+}
+
+// Leaf parses and returns the SVID's leaf certificate.
+func (s *SVID) Leaf() (*x509.Certificate, error) {
+	if len(s.Certificates) == 0 {
+		return nil, fmt.Errorf("identity: SVID has no certificates")
+	}
+	return x509.ParseCertificate(s.Certificates[0])
+}
+
+// Source supplies the current SVID. It has no rotation callback: callers
+// fetch the SVID again on every use, so a rotated SVID takes effect on the
+// next call. FileSource and StepSource in this package cover the common
+// sidecar and CA-server cases without a SPIFFE Workload API client; this
+// package has no Workload API-backed Source.
+type Source interface {
+	// GetX509SVID returns the current SVID. Callers should call this on
+	// every TLS handshake (via tls.Config.GetCertificate) rather than
+	// caching it, so rotation takes effect without a restart.
+	GetX509SVID() (*SVID, error)
+}
+
+// Authorizer decides whether a peer's SPIFFE ID is allowed to connect.
+type Authorizer func(spiffeID string) bool
+
+// AuthorizeID allows only the exact SPIFFE ID trustedID.
+func AuthorizeID(trustedID string) Authorizer {
+	return func(spiffeID string) bool { return spiffeID == trustedID }
+}
+
+// AuthorizeAny allows any of the given SPIFFE IDs.
+func AuthorizeAny(trustedIDs ...string) Authorizer {
+	set := make(map[string]bool, len(trustedIDs))
+	for _, id := range trustedIDs {
+		set[id] = true
+	}
+	return func(spiffeID string) bool { return set[spiffeID] }
+}
+
+// AuthorizeMemberOf allows any SPIFFE ID in trustDomain, e.g.
+// "example.org" matches "spiffe://example.org/ns/default/sa/anything".
+func AuthorizeMemberOf(trustDomain string) Authorizer {
+	prefix := "spiffe://" + trustDomain + "/"
+	return func(spiffeID string) bool { return strings.HasPrefix(spiffeID, prefix) }
+}
+
+// SPIFFEIDFromCertificate returns the SPIFFE ID carried in cert's URI SANs,
+// or an error if it has none or more than one (a SPIFFE leaf certificate
+// must carry exactly one URI SAN).
+func SPIFFEIDFromCertificate(cert *x509.Certificate) (string, error) {
+	var ids []string
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" {
+			ids = append(ids, uri.String())
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("identity: certificate has no spiffe:// URI SAN")
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("identity: certificate has multiple spiffe:// URI SANs: %v", ids)
+	}
+}
@@ -0,0 +1,116 @@
+package identity
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func selfSignedWithSPIFFEID(t *testing.T, ids ...string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	var uris []*url.URL
+	for _, id := range ids {
+		u, err := url.Parse(id)
+		if err != nil {
+			t.Fatalf("parsing SPIFFE ID %q: %v", id, err)
+		}
+		uris = append(uris, u)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         uris,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert
+}
+
+func TestSPIFFEIDFromCertificate(t *testing.T) {
+	cert := selfSignedWithSPIFFEID(t, "spiffe://example.org/ns/default/sa/compliance-admission")
+
+	id, err := SPIFFEIDFromCertificate(cert)
+	if err != nil {
+		t.Fatalf("SPIFFEIDFromCertificate: %v", err)
+	}
+	if id != "spiffe://example.org/ns/default/sa/compliance-admission" {
+		t.Fatalf("unexpected SPIFFE ID: %s", id)
+	}
+}
+
+func TestSPIFFEIDFromCertificateNoURI(t *testing.T) {
+	cert := selfSignedWithSPIFFEID(t)
+
+	if _, err := SPIFFEIDFromCertificate(cert); err == nil {
+		t.Fatal("expected an error for a certificate with no spiffe:// URI SAN")
+	}
+}
+
+func TestSPIFFEIDFromCertificateMultipleURIs(t *testing.T) {
+	cert := selfSignedWithSPIFFEID(t,
+		"spiffe://example.org/ns/default/sa/a",
+		"spiffe://example.org/ns/default/sa/b",
+	)
+
+	if _, err := SPIFFEIDFromCertificate(cert); err == nil {
+		t.Fatal("expected an error for a certificate with multiple spiffe:// URI SANs")
+	}
+}
+
+func TestAuthorizeID(t *testing.T) {
+	authz := AuthorizeID("spiffe://example.org/ns/default/sa/a")
+
+	if !authz("spiffe://example.org/ns/default/sa/a") {
+		t.Fatal("expected matching SPIFFE ID to be authorized")
+	}
+	if authz("spiffe://example.org/ns/default/sa/b") {
+		t.Fatal("expected different SPIFFE ID to be rejected")
+	}
+}
+
+func TestAuthorizeAny(t *testing.T) {
+	authz := AuthorizeAny(
+		"spiffe://example.org/ns/default/sa/a",
+		"spiffe://example.org/ns/default/sa/b",
+	)
+
+	if !authz("spiffe://example.org/ns/default/sa/b") {
+		t.Fatal("expected listed SPIFFE ID to be authorized")
+	}
+	if authz("spiffe://example.org/ns/default/sa/c") {
+		t.Fatal("expected unlisted SPIFFE ID to be rejected")
+	}
+}
+
+func TestAuthorizeMemberOf(t *testing.T) {
+	authz := AuthorizeMemberOf("example.org")
+
+	if !authz("spiffe://example.org/ns/default/sa/anything") {
+		t.Fatal("expected member of trust domain to be authorized")
+	}
+	if authz("spiffe://other.org/ns/default/sa/anything") {
+		t.Fatal("expected member of a different trust domain to be rejected")
+	}
+}
@@ -0,0 +1,137 @@
+package identity
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// ServerTLSConfig returns a *tls.Config for a server that authenticates
+// itself with source's SVID, requires a client certificate, and accepts
+// the connection only if the client's SPIFFE ID satisfies authorizer.
+// Pass a nil authorizer to accept any SVID from a trust-bundle-verified
+// peer without an additional identity check.
+func ServerTLSConfig(source Source, authorizer Authorizer) (*tls.Config, error) {
+	if source == nil {
+		return nil, fmt.Errorf("identity: ServerTLSConfig requires a non-nil Source")
+	}
+
+	cfg := &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return svidCertificate(source)
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			svid, err := source.GetX509SVID()
+			if err != nil {
+				return nil, fmt.Errorf("identity: fetching SVID: %w", err)
+			}
+			inner := &tls.Config{
+				ClientAuth: tls.RequireAndVerifyClientCert,
+				ClientCAs:  svid.TrustBundle,
+			}
+			inner.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				return svidCertificate(source)
+			}
+			inner.VerifyPeerCertificate = verifyAuthorized(authorizer)
+			return inner, nil
+		},
+	}
+	return cfg, nil
+}
+
+// ClientTLSConfig returns a *tls.Config for a client that authenticates
+// itself with source's SVID and accepts the server only if its SPIFFE ID
+// satisfies authorizer.
+func ClientTLSConfig(source Source, authorizer Authorizer) (*tls.Config, error) {
+	if source == nil {
+		return nil, fmt.Errorf("identity: ClientTLSConfig requires a non-nil Source")
+	}
+
+	svid, err := source.GetX509SVID()
+	if err != nil {
+		return nil, fmt.Errorf("identity: fetching SVID: %w", err)
+	}
+
+	return &tls.Config{
+		RootCAs: svid.TrustBundle,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return svidCertificate(source)
+		},
+		// InsecureSkipVerify plus our own VerifyPeerCertificate is the
+		// standard way to replace Go's hostname-based verification with
+		// SPIFFE-ID-based verification; the trust bundle check still runs
+		// inside verifyChainAndAuthorized via x509 chain building.
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verifyChainAndAuthorized(svid.TrustBundle, authorizer),
+	}, nil
+}
+
+func svidCertificate(source Source) (*tls.Certificate, error) {
+	svid, err := source.GetX509SVID()
+	if err != nil {
+		return nil, fmt.Errorf("identity: fetching SVID: %w", err)
+	}
+	return &tls.Certificate{Certificate: svid.Certificates, PrivateKey: svid.PrivateKey}, nil
+}
+
+// verifyAuthorized checks the already-chain-verified peer certificate's
+// SPIFFE ID against authorizer; used on the server side where ClientCAs is
+// already set, so Go has done chain verification before this runs.
+func verifyAuthorized(authorizer Authorizer) func([][]byte, [][]*x509.Certificate) error {
+	return func(_ [][]byte, chains [][]*x509.Certificate) error {
+		return authorizeChains(chains, authorizer)
+	}
+}
+
+// verifyChainAndAuthorized does the chain verification Go's TLS stack
+// would normally do (skipped here because InsecureSkipVerify disables
+// hostname checks) against bundle, then checks the SPIFFE ID.
+func verifyChainAndAuthorized(bundle *x509.CertPool, authorizer Authorizer) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("identity: peer presented no certificates")
+		}
+
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("identity: parsing peer certificate: %w", err)
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, raw := range rawCerts[1:] {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("identity: parsing peer intermediate certificate: %w", err)
+			}
+			intermediates.AddCert(cert)
+		}
+
+		chains, err := leaf.Verify(x509.VerifyOptions{Roots: bundle, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+		if err != nil {
+			return fmt.Errorf("identity: verifying peer certificate chain: %w", err)
+		}
+
+		return authorizeChains(chains, authorizer)
+	}
+}
+
+func authorizeChains(chains [][]*x509.Certificate, authorizer Authorizer) error {
+	if authorizer == nil {
+		return nil
+	}
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return fmt.Errorf("identity: no verified certificate chain to authorize")
+	}
+
+	spiffeID, err := SPIFFEIDFromCertificate(chains[0][0])
+	if err != nil {
+		return err
+	}
+
+	if !authorizer(spiffeID) {
+		return fmt.Errorf("identity: peer SPIFFE ID %q is not authorized", spiffeID)
+	}
+
+	return nil
+}
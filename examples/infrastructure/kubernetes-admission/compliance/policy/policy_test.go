@@ -0,0 +1,148 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBundleEvaluateAllowDeny(t *testing.T) {
+	bundle := Bundle{
+		Framework: "gdpr",
+		Control:   "Art.32",
+		Rules: []Rule{
+			{ID: "secret-encryption-annotation", When: map[string]interface{}{"input.hasEncryptionAnnotation": false}, Result: Deny, Reason: "missing annotation"},
+			{ID: "default-allow", When: map[string]interface{}{}, Result: Allow},
+		},
+	}
+
+	deny := bundle.Evaluate(Input{
+		Framework: "gdpr",
+		Control:   "Art.32",
+		Inputs:    map[string]interface{}{"hasEncryptionAnnotation": false},
+	})
+	if deny.Result != Deny || deny.RuleID != "secret-encryption-annotation" {
+		t.Fatalf("expected deny via secret-encryption-annotation, got %+v", deny)
+	}
+
+	allow := bundle.Evaluate(Input{
+		Framework: "gdpr",
+		Control:   "Art.32",
+		Inputs:    map[string]interface{}{"hasEncryptionAnnotation": true},
+	})
+	if allow.Result != Allow || allow.RuleID != "default-allow" {
+		t.Fatalf("expected default-allow, got %+v", allow)
+	}
+}
+
+func TestBundleEngineEvaluateUnknownControlAllows(t *testing.T) {
+	engine := NewBundleEngine()
+
+	decision, err := engine.Evaluate(context.Background(), Input{Framework: "soc2", Control: "CC7.2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Result != Allow || decision.RuleID != "no-bundle" {
+		t.Fatalf("expected no-bundle allow, got %+v", decision)
+	}
+}
+
+func TestBundleEngineEvaluatePerFramework(t *testing.T) {
+	engine := NewBundleEngine()
+	engine.SetBundle(Bundle{
+		Framework: "gdpr",
+		Control:   "Art.5(1)(f)",
+		Rules: []Rule{
+			{ID: "pod-non-root", When: map[string]interface{}{"input.resource": "Pod", "input.allNonRoot": false}, Result: Deny},
+			{ID: "default-allow", When: map[string]interface{}{}, Result: Allow},
+		},
+	})
+	engine.SetBundle(Bundle{
+		Framework: "soc2",
+		Control:   "CC6.1",
+		Rules: []Rule{
+			{ID: "pod-non-root", When: map[string]interface{}{"input.resource": "Pod", "input.allNonRoot": false}, Result: Deny},
+			{ID: "default-allow", When: map[string]interface{}{}, Result: Allow},
+		},
+	})
+
+	gdprDecision, err := engine.Evaluate(context.Background(), Input{
+		Framework: "gdpr",
+		Control:   "Art.5(1)(f)",
+		Inputs:    map[string]interface{}{"resource": "Pod", "allNonRoot": false},
+	})
+	if err != nil || gdprDecision.Result != Deny {
+		t.Fatalf("expected gdpr deny, got %+v, err=%v", gdprDecision, err)
+	}
+
+	soc2Decision, err := engine.Evaluate(context.Background(), Input{
+		Framework: "soc2",
+		Control:   "CC6.1",
+		Inputs:    map[string]interface{}{"resource": "Pod", "allNonRoot": true},
+	})
+	if err != nil || soc2Decision.Result != Allow {
+		t.Fatalf("expected soc2 allow, got %+v, err=%v", soc2Decision, err)
+	}
+}
+
+func TestLoaderLoadsBundlesFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	bundle := `{
+		"framework": "gdpr",
+		"control": "Art.32",
+		"rules": [
+			{"id": "deny-all", "when": {}, "result": "deny", "reason": "test"}
+		]
+	}`
+	if err := os.WriteFile(filepath.Join(dir, "gdpr-art-32.json"), []byte(bundle), 0o644); err != nil {
+		t.Fatalf("writing fixture bundle: %v", err)
+	}
+
+	engine := NewBundleEngine()
+	loader := NewLoader(dir, engine)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	decision, err := engine.Evaluate(context.Background(), Input{Framework: "gdpr", Control: "Art.32"})
+	if err != nil || decision.Result != Deny || decision.RuleID != "deny-all" {
+		t.Fatalf("expected deny-all, got %+v, err=%v", decision, err)
+	}
+}
+
+func TestLoaderHotReloadsChangedBundle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gdpr-art-32.json")
+	original := `{"framework":"gdpr","control":"Art.32","rules":[{"id":"deny-all","when":{},"result":"deny"}]}`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatalf("writing fixture bundle: %v", err)
+	}
+
+	engine := NewBundleEngine()
+	loader := NewLoader(dir, engine)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	updated := `{"framework":"gdpr","control":"Art.32","rules":[{"id":"allow-all","when":{},"result":"allow"}]}`
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		t.Fatalf("writing updated bundle: %v", err)
+	}
+	// Force the mtime forward so the poll-based reload sees a change even on
+	// filesystems with coarse mtime resolution.
+	future := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	if err := loader.reloadChanged(); err != nil {
+		t.Fatalf("reloadChanged: %v", err)
+	}
+
+	decision, err := engine.Evaluate(context.Background(), Input{Framework: "gdpr", Control: "Art.32"})
+	if err != nil || decision.Result != Allow || decision.RuleID != "allow-all" {
+		t.Fatalf("expected allow-all after reload, got %+v, err=%v", decision, err)
+	}
+}
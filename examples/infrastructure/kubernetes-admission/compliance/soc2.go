@@ -0,0 +1,160 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluohq/compliance-as-code/examples/kubernetes-admission/compliance/policy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SOC 2 compliance controls
+const (
+	CC6_1 = "CC6.1" // Logical Access Controls
+	CC6_6 = "CC6.6" // Logical and Physical Access Controls
+	CC6_8 = "CC6.8" // Change Management
+	CC7_2 = "CC7.2" // System Monitoring
+)
+
+var soc2Tracer = otel.Tracer("compliance-soc2")
+
+// SOC2Span represents a SOC 2 compliance evidence span
+type SOC2Span struct {
+	span         trace.Span
+	ctx          context.Context
+	start        time.Time
+	control      string
+	engine       policy.Engine
+	inputs       map[string]interface{}
+	outputs      map[string]interface{}
+	decided      bool
+	lastDecision policy.Decision
+}
+
+// BeginSOC2Span starts a new SOC 2 evidence span
+func BeginSOC2Span(ctx context.Context, control string, opts ...Option) *SOC2Span {
+	cfg := &spanConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	spanCtx, span := soc2Tracer.Start(ctx, "soc2."+control)
+
+	span.SetAttributes(
+		attribute.String("compliance.framework", "soc2"),
+		attribute.String("compliance.control", control),
+		attribute.String("compliance.type", "evidence"),
+	)
+
+	return &SOC2Span{
+		span:    span,
+		ctx:     spanCtx,
+		start:   time.Now(),
+		control: control,
+		engine:  cfg.engine,
+		inputs:  make(map[string]interface{}),
+		outputs: make(map[string]interface{}),
+	}
+}
+
+// SetInput adds an input attribute to the evidence span
+func (s *SOC2Span) SetInput(key string, value interface{}) {
+	s.inputs[key] = value
+	s.setAttribute("input."+key, value)
+}
+
+// SetOutput adds an output attribute to the evidence span
+func (s *SOC2Span) SetOutput(key string, value interface{}) {
+	s.outputs[key] = value
+	s.setAttribute("output."+key, value)
+}
+
+// SetIdentity records the caller's workload identity on the evidence span:
+// its SPIFFE ID, and whether it was established via a short-lived mTLS
+// certificate or a bearer token. Call it before End/EndWithError once the
+// caller's credentials have been inspected.
+func (s *SOC2Span) SetIdentity(spiffeID, authMethod string) {
+	s.span.SetAttributes(
+		attribute.String("compliance.identity.spiffe_id", spiffeID),
+		attribute.String("compliance.identity.auth_method", authMethod),
+	)
+}
+
+// End completes the evidence span successfully
+func (s *SOC2Span) End() {
+	s.Decide()
+	s.span.SetAttributes(
+		attribute.String("compliance.result", "success"),
+		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
+	)
+	s.span.End()
+}
+
+// EndWithError completes the evidence span with an error
+func (s *SOC2Span) EndWithError(err error) {
+	s.Decide()
+	s.span.SetAttributes(
+		attribute.String("compliance.result", "failure"),
+		attribute.String("compliance.error", err.Error()),
+		attribute.Int64("compliance.duration_ms", time.Since(s.start).Milliseconds()),
+	)
+	s.span.RecordError(err)
+	s.span.End()
+}
+
+// Decide evaluates the configured policy engine against the evidence
+// collected so far and records the outcome as a compliance.decision
+// attribute, returning it so callers can act on it before the span ends.
+// See GDPRSpan.Decide for the idempotency contract.
+func (s *SOC2Span) Decide() policy.Decision {
+	if s.decided {
+		return s.lastDecision
+	}
+	s.decided = true
+
+	if s.engine == nil {
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "no-engine"}
+		return s.lastDecision
+	}
+
+	decision, err := s.engine.Evaluate(s.ctx, policy.Input{
+		Framework: "soc2",
+		Control:   s.control,
+		Inputs:    s.inputs,
+		Outputs:   s.outputs,
+	})
+	if err != nil {
+		s.span.SetAttributes(attribute.String("compliance.decision.error", err.Error()))
+		s.lastDecision = policy.Decision{Result: policy.Allow, RuleID: "engine-error"}
+		return s.lastDecision
+	}
+
+	s.span.SetAttributes(
+		attribute.String("compliance.decision", string(decision.Result)),
+		attribute.String("compliance.decision.rule_id", decision.RuleID),
+	)
+	if decision.Reason != "" {
+		s.span.SetAttributes(attribute.String("compliance.decision.reason", decision.Reason))
+	}
+
+	s.lastDecision = decision
+	return decision
+}
+
+func (s *SOC2Span) setAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case int64:
+		s.span.SetAttributes(attribute.Int64(key, v))
+	case bool:
+		s.span.SetAttributes(attribute.Bool(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprintf("%v", v)))
+	}
+}
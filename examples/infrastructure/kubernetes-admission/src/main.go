@@ -2,18 +2,42 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/fluohq/compliance-as-code/examples/kubernetes-admission/compliance"
+	"github.com/fluohq/compliance-as-code/examples/kubernetes-admission/compliance/export"
+	"github.com/fluohq/compliance-as-code/examples/kubernetes-admission/compliance/identity"
+	"github.com/fluohq/compliance-as-code/examples/kubernetes-admission/compliance/policy"
+	"go.opentelemetry.io/otel"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// AdmissionController validates Kubernetes resources for compliance
-type AdmissionController struct{}
+// defaultPolicyBundleDir is the fallback bundle directory when
+// POLICY_BUNDLE_DIR is unset. In cluster it's typically overridden to the
+// mount point of a ConfigMap carrying the same *.json bundle files.
+const defaultPolicyBundleDir = "policies"
+
+// defaultWALDir is the fallback WAL directory when COMPLIANCE_WAL_DIR is
+// unset. In cluster it's typically overridden to a PersistentVolume mount
+// so evidence survives pod restarts.
+const defaultWALDir = "/var/lib/compliance-evidence"
+
+// AdmissionController validates Kubernetes resources for compliance. The
+// actual allow/deny/warn decisions live in policy bundles loaded by
+// engine/loader, not in this struct; AdmissionController's job is to
+// extract the facts each control needs from the incoming resource and hand
+// them to the policy engine via the compliance evidence spans.
+type AdmissionController struct {
+	engine *policy.BundleEngine
+}
 
 // ServeHTTP handles admission webhook requests
 func (ac *AdmissionController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -24,7 +48,7 @@ func (ac *AdmissionController) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response := ac.handleAdmission(admissionReview.Request)
+	response := ac.handleAdmission(r, admissionReview.Request)
 	admissionReview.Response = response
 
 	w.Header().Set("Content-Type", "application/json")
@@ -32,17 +56,18 @@ func (ac *AdmissionController) ServeHTTP(w http.ResponseWriter, r *http.Request)
 }
 
 // handleAdmission processes admission request and emits compliance evidence
-func (ac *AdmissionController) handleAdmission(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+func (ac *AdmissionController) handleAdmission(r *http.Request, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
 	ctx := context.Background()
 
 	// SOC 2 CC6.1: Authorization - validate resource creation
-	soc2Span := compliance.BeginSOC2Span(ctx, compliance.CC6_1)
+	soc2Span := compliance.BeginSOC2Span(ctx, compliance.CC6_1, compliance.WithPolicyEngine(ac.engine))
 	defer soc2Span.End()
 
 	soc2Span.SetInput("resource", req.Kind.Kind)
 	soc2Span.SetInput("namespace", req.Namespace)
 	soc2Span.SetInput("operation", string(req.Operation))
 	soc2Span.SetInput("user", req.UserInfo.Username)
+	soc2Span.SetIdentity(callerIdentity(r))
 
 	switch req.Kind.Kind {
 	case "Pod":
@@ -60,55 +85,55 @@ func (ac *AdmissionController) handleAdmission(req *admissionv1.AdmissionRequest
 	}
 }
 
-// validatePod ensures pods meet GDPR security requirements
+// validatePod extracts Pod security facts and lets the GDPR Art.5(1)(f)
+// policy bundle decide whether the pod is compliant.
 func (ac *AdmissionController) validatePod(ctx context.Context, req *admissionv1.AdmissionRequest, soc2Span *compliance.SOC2Span) *admissionv1.AdmissionResponse {
-	// GDPR Art.5(1)(f): Security of Processing
-	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_51f)
+	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_51f, compliance.WithPolicyEngine(ac.engine))
 	defer gdprSpan.End()
 
 	gdprSpan.SetInput("resource", "Pod")
 	gdprSpan.SetInput("namespace", req.Namespace)
 	gdprSpan.SetInput("validation", "security_controls")
+	soc2Span.SetInput("validation", "security_controls")
 
 	var pod corev1.Pod
 	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
 		return denyAdmission(err.Error(), gdprSpan, soc2Span)
 	}
 
-	violations := []string{}
-
-	// Check: Containers must not run as root
+	allNonRoot := true
+	allReadOnlyFS := true
 	for _, container := range pod.Spec.Containers {
 		if container.SecurityContext == nil || container.SecurityContext.RunAsNonRoot == nil || !*container.SecurityContext.RunAsNonRoot {
-			violations = append(violations, fmt.Sprintf("Container %s must run as non-root (GDPR Art.5(1)(f))", container.Name))
+			allNonRoot = false
 		}
-
-		// Check: ReadOnlyRootFilesystem required for data protection
 		if container.SecurityContext == nil || container.SecurityContext.ReadOnlyRootFilesystem == nil || !*container.SecurityContext.ReadOnlyRootFilesystem {
-			violations = append(violations, fmt.Sprintf("Container %s must have read-only root filesystem", container.Name))
+			allReadOnlyFS = false
 		}
 	}
 
-	// Check: Pod must not use host network (data isolation)
-	if pod.Spec.HostNetwork {
-		violations = append(violations, "Pod must not use host network (data isolation required)")
+	facts := map[string]interface{}{
+		"allNonRoot":    allNonRoot,
+		"allReadOnlyFs": allReadOnlyFS,
+		"hostNetwork":   pod.Spec.HostNetwork,
+		"hostPID":       pod.Spec.HostPID,
 	}
-
-	// Check: Pod must not use host PID namespace
-	if pod.Spec.HostPID {
-		violations = append(violations, "Pod must not use host PID namespace")
+	for k, v := range facts {
+		gdprSpan.SetInput(k, v)
+		soc2Span.SetInput(k, v)
 	}
 
-	if len(violations) > 0 {
-		gdprSpan.SetOutput("violations", violations)
+	decision := gdprSpan.Decide()
+	soc2Span.SetOutput("compliant", decision.Result == policy.Allow)
+
+	if decision.Result == policy.Deny {
 		gdprSpan.SetOutput("compliant", false)
 		soc2Span.SetOutput("authorized", false)
 		soc2Span.SetOutput("result", "denied")
-		return denyAdmission(fmt.Sprintf("Pod violates GDPR security requirements: %v", violations), gdprSpan, soc2Span)
+		return denyAdmission(fmt.Sprintf("Pod violates GDPR security requirements (%s): %s", decision.RuleID, decision.Reason), gdprSpan, soc2Span)
 	}
 
 	gdprSpan.SetOutput("compliant", true)
-	gdprSpan.SetOutput("securityControlsValidated", 4)
 	soc2Span.SetOutput("authorized", true)
 	soc2Span.SetOutput("result", "allowed")
 
@@ -117,37 +142,40 @@ func (ac *AdmissionController) validatePod(ctx context.Context, req *admissionv1
 	}
 }
 
-// validateSecret ensures secrets are encrypted at rest
+// validateSecret extracts Secret encryption facts and lets the GDPR Art.32
+// policy bundle decide whether the secret is compliant.
 func (ac *AdmissionController) validateSecret(ctx context.Context, req *admissionv1.AdmissionRequest, soc2Span *compliance.SOC2Span) *admissionv1.AdmissionResponse {
-	// GDPR Art.32: Security of Processing (encryption)
-	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_32)
+	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_32, compliance.WithPolicyEngine(ac.engine))
 	defer gdprSpan.End()
 
 	gdprSpan.SetInput("resource", "Secret")
 	gdprSpan.SetInput("namespace", req.Namespace)
 	gdprSpan.SetInput("validation", "encryption_at_rest")
+	soc2Span.SetInput("validation", "encryption_at_rest")
 
 	var secret corev1.Secret
 	if err := json.Unmarshal(req.Object.Raw, &secret); err != nil {
 		return denyAdmission(err.Error(), gdprSpan, soc2Span)
 	}
 
-	// Check annotations for encryption evidence
-	if secret.Annotations == nil {
-		secret.Annotations = make(map[string]string)
-	}
+	provider, hasAnnotation := secret.Annotations["encryption.kubernetes.io/provider"]
+
+	gdprSpan.SetInput("hasEncryptionAnnotation", hasAnnotation)
+	soc2Span.SetInput("hasEncryptionAnnotation", hasAnnotation)
+
+	decision := gdprSpan.Decide()
 
-	// Require encryption-at-rest annotation
-	if _, ok := secret.Annotations["encryption.kubernetes.io/provider"]; !ok {
+	if decision.Result == policy.Deny {
 		gdprSpan.SetOutput("encrypted", false)
 		gdprSpan.SetOutput("compliant", false)
 		soc2Span.SetOutput("authorized", false)
-		return denyAdmission("Secret must have encryption.kubernetes.io/provider annotation (GDPR Art.32)", gdprSpan, soc2Span)
+		soc2Span.SetOutput("result", "denied")
+		return denyAdmission(fmt.Sprintf("Secret violates GDPR Art.32 (%s): %s", decision.RuleID, decision.Reason), gdprSpan, soc2Span)
 	}
 
 	gdprSpan.SetOutput("encrypted", true)
 	gdprSpan.SetOutput("compliant", true)
-	gdprSpan.SetOutput("encryptionProvider", secret.Annotations["encryption.kubernetes.io/provider"])
+	gdprSpan.SetOutput("encryptionProvider", provider)
 	soc2Span.SetOutput("authorized", true)
 	soc2Span.SetOutput("result", "allowed")
 
@@ -156,42 +184,41 @@ func (ac *AdmissionController) validateSecret(ctx context.Context, req *admissio
 	}
 }
 
-// validatePVC ensures persistent volumes are encrypted
+// validatePVC extracts PVC storage-class facts and lets the GDPR
+// Art.5(1)(f) policy bundle decide whether the volume is compliant.
 func (ac *AdmissionController) validatePVC(ctx context.Context, req *admissionv1.AdmissionRequest, soc2Span *compliance.SOC2Span) *admissionv1.AdmissionResponse {
-	// GDPR Art.5(1)(f): Security of Processing (data at rest)
-	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_51f)
+	gdprSpan := compliance.BeginGDPRSpan(ctx, compliance.Art_51f, compliance.WithPolicyEngine(ac.engine))
 	defer gdprSpan.End()
 
 	gdprSpan.SetInput("resource", "PersistentVolumeClaim")
 	gdprSpan.SetInput("namespace", req.Namespace)
 	gdprSpan.SetInput("validation", "volume_encryption")
+	soc2Span.SetInput("validation", "volume_encryption")
 
 	var pvc corev1.PersistentVolumeClaim
 	if err := json.Unmarshal(req.Object.Raw, &pvc); err != nil {
 		return denyAdmission(err.Error(), gdprSpan, soc2Span)
 	}
 
-	// Check for encrypted storage class
-	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
-		gdprSpan.SetOutput("encrypted", false)
-		return denyAdmission("PVC must specify encrypted storage class (GDPR Art.5(1)(f))", gdprSpan, soc2Span)
+	storageClass := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
 	}
 
-	storageClass := *pvc.Spec.StorageClassName
-	encryptedClasses := []string{"encrypted-gp3", "encrypted-ssd", "gp3-encrypted"}
+	encryptedClasses := map[string]bool{"encrypted-gp3": true, "encrypted-ssd": true, "gp3-encrypted": true}
 
-	encrypted := false
-	for _, class := range encryptedClasses {
-		if storageClass == class {
-			encrypted = true
-			break
-		}
-	}
+	gdprSpan.SetInput("storageClass", storageClass)
+	gdprSpan.SetInput("storageClassEncrypted", encryptedClasses[storageClass])
+	soc2Span.SetInput("storageClassEncrypted", encryptedClasses[storageClass])
 
-	if !encrypted {
+	decision := gdprSpan.Decide()
+
+	if decision.Result == policy.Deny {
 		gdprSpan.SetOutput("encrypted", false)
 		gdprSpan.SetOutput("storageClass", storageClass)
-		return denyAdmission(fmt.Sprintf("PVC must use encrypted storage class, got: %s", storageClass), gdprSpan, soc2Span)
+		soc2Span.SetOutput("authorized", false)
+		soc2Span.SetOutput("result", "denied")
+		return denyAdmission(fmt.Sprintf("PVC violates GDPR Art.5(1)(f) (%s): %s, storage class: %s", decision.RuleID, decision.Reason, storageClass), gdprSpan, soc2Span)
 	}
 
 	gdprSpan.SetOutput("encrypted", true)
@@ -217,8 +244,129 @@ func denyAdmission(message string, gdprSpan *compliance.GDPRSpan, soc2Span *comp
 	}
 }
 
+// callerIdentity reports how the caller authenticated: its SPIFFE ID and
+// "mtls" if it presented a client certificate over mutual TLS, or "bearer"
+// if it only presented an Authorization header, or "none" if neither.
+func callerIdentity(r *http.Request) (spiffeID, authMethod string) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		id, err := identity.SPIFFEIDFromCertificate(r.TLS.PeerCertificates[0])
+		if err == nil {
+			return id, "mtls"
+		}
+	}
+	if r.Header.Get("Authorization") != "" {
+		return "", "bearer"
+	}
+	return "", "none"
+}
+
+// tlsConfig builds the admission controller's server TLS config from
+// environment-driven workload identity sources, preferring a SPIFFE-style
+// file source (the sidecar/SPIRE pattern) and falling back to a Step/CFSSL
+// CA when SPIFFE isn't available. Returns nil, nil if neither is
+// configured, so the caller can fall back to a static certificate file.
+func tlsConfig(ctx context.Context) (*tls.Config, error) {
+	trustDomain := os.Getenv("SPIFFE_TRUST_DOMAIN")
+	var authorizer identity.Authorizer
+	if trustDomain != "" {
+		authorizer = identity.AuthorizeMemberOf(trustDomain)
+	}
+
+	if svidPath := os.Getenv("SPIFFE_SVID_PATH"); svidPath != "" {
+		source, err := identity.NewFileSource(svidPath, os.Getenv("SPIFFE_KEY_PATH"), os.Getenv("SPIFFE_BUNDLE_PATH"))
+		if err != nil {
+			return nil, fmt.Errorf("loading SPIFFE SVID: %w", err)
+		}
+		go func() {
+			if err := source.Watch(ctx, 30*time.Second); err != nil && ctx.Err() == nil {
+				log.Printf("SPIFFE SVID watcher stopped: %v", err)
+			}
+		}()
+		return identity.ServerTLSConfig(source, authorizer)
+	}
+
+	if caURL := os.Getenv("CA_URL"); caURL != "" {
+		rotation := 1 * time.Hour
+		if v := os.Getenv("CERT_ROTATION_INTERVAL"); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("parsing CERT_ROTATION_INTERVAL: %w", err)
+			}
+			rotation = parsed
+		}
+		source, err := identity.NewStepSource(ctx, caURL, os.Getenv("PROVISIONER_TOKEN"), os.Getenv("SPIFFE_ID"), rotation)
+		if err != nil {
+			return nil, fmt.Errorf("issuing certificate from %s: %w", caURL, err)
+		}
+		go func() {
+			if err := source.Watch(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Step certificate renewal stopped: %v", err)
+			}
+		}()
+		return identity.ServerTLSConfig(source, authorizer)
+	}
+
+	return nil, nil
+}
+
+// configureDurableExport wires up the durable evidence exporter and
+// installs it as the global tracer provider when COMPLIANCE_OTLP_ENDPOINT
+// is set. It returns a nil provider, leaving the OTel no-op tracer in
+// place, if evidence export isn't configured.
+func configureDurableExport() (*export.DurableTracerProvider, error) {
+	endpoint := os.Getenv("COMPLIANCE_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	walDir := os.Getenv("COMPLIANCE_WAL_DIR")
+	if walDir == "" {
+		walDir = defaultWALDir
+	}
+
+	var opts []export.Option
+	if os.Getenv("COMPLIANCE_OTLP_INSECURE") == "true" {
+		opts = append(opts, export.WithInsecure())
+	}
+
+	tp, err := export.NewDurableTracerProvider(walDir, endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("opening evidence WAL at %s: %w", walDir, err)
+	}
+
+	otel.SetTracerProvider(tp)
+	return tp, nil
+}
+
 func main() {
-	controller := &AdmissionController{}
+	tp, err := configureDurableExport()
+	if err != nil {
+		log.Fatalf("configuring durable evidence export: %v", err)
+	}
+	if tp != nil {
+		defer tp.Shutdown(context.Background())
+	}
+
+	bundleDir := os.Getenv("POLICY_BUNDLE_DIR")
+	if bundleDir == "" {
+		bundleDir = defaultPolicyBundleDir
+	}
+
+	engine := policy.NewBundleEngine()
+	loader := policy.NewLoader(bundleDir, engine)
+	if err := loader.Load(); err != nil {
+		log.Fatalf("loading policy bundles from %s: %v", bundleDir, err)
+	}
+
+	watchCtx, cancelWatch := context.WithCancel(context.Background())
+	defer cancelWatch()
+	go func() {
+		if err := loader.Watch(watchCtx, 5*time.Second); err != nil && watchCtx.Err() == nil {
+			log.Printf("policy bundle watcher stopped: %v", err)
+		}
+	}()
+
+	controller := &AdmissionController{engine: engine}
 
 	http.HandleFunc("/validate", controller.ServeHTTP)
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -228,7 +376,24 @@ func main() {
 
 	fmt.Println("Starting Kubernetes admission controller on :8443")
 	fmt.Println("Emitting compliance evidence for GDPR + SOC 2")
-	if err := http.ListenAndServeTLS(":8443", "/certs/tls.crt", "/certs/tls.key", nil); err != nil {
+	fmt.Printf("Policy bundles loaded from %s (hot-reloaded every 5s)\n", bundleDir)
+
+	mtlsConfig, err := tlsConfig(watchCtx)
+	if err != nil {
+		log.Fatalf("configuring mTLS: %v", err)
+	}
+
+	server := &http.Server{Addr: ":8443"}
+	if mtlsConfig != nil {
+		fmt.Println("Requiring mTLS client certificates (SPIFFE workload identity)")
+		server.TLSConfig = mtlsConfig
+		if err := server.ListenAndServeTLS("", ""); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	if err := server.ListenAndServeTLS("/certs/tls.crt", "/certs/tls.key"); err != nil {
 		panic(err)
 	}
 }